@@ -7,16 +7,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment/loader"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/server"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
 func init() {
@@ -35,9 +39,12 @@ func main() {
 	var config *environment.Config
 	var err error
 
-	if len(os.Args) > 1 && os.Args[1] == "local" {
+	args := os.Args[1:]
+
+	if len(args) > 0 && args[0] == "local" {
 		slog.InfoContext(ctx, "loading environment from .env file")
 		config, err = loader.GetEnvironmentFromFile(ctx, ".env")
+		args = args[1:]
 	} else {
 		config, err = loader.GetEnvironment(ctx)
 	}
@@ -49,6 +56,12 @@ func main() {
 
 	logger.SetupLog(config)
 
+	shutdownTracing, err := tracing.Setup(ctx, config)
+	if err != nil {
+		slog.ErrorContext(ctx, "error setting up the tracing", "error", err)
+		panic(err)
+	}
+
 	cloudConfig, err := awsConfig.LoadDefaultConfig(ctx)
 	if err != nil {
 		panic(err)
@@ -58,6 +71,8 @@ func main() {
 		cloudConfig.BaseEndpoint = aws.String(config.CloudConfig.BaseEndpoint)
 	}
 
+	otelaws.AppendMiddlewares(&cloudConfig.APIOptions)
+
 	secret := cloud.NewSecretService(cloudConfig)
 
 	dbUrl, err := secret.GetSecret(ctx, config.DbConfig.UrlSecretName)
@@ -68,12 +83,131 @@ func main() {
 
 	config.DbConfig.Url = dbUrl
 
+	if len(args) > 0 && args[0] == "migrate" {
+		databaseService := database.NewDatabase(config)
+
+		err := databaseService.WaitUntilReady(ctx)
+		if err == nil {
+			err = runMigrateCommand(ctx, databaseService.GetInstance(), args[1:])
+		}
+
+		if err := databaseService.Close(); err != nil {
+			slog.ErrorContext(ctx, "error closing the database connections", "error", err)
+		}
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error running the migrations", "error", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if config.AuthConfig.IsSecretNameSet() {
+		authSecret, err := secret.GetSecret(ctx, config.AuthConfig.SecretName)
+		if err != nil {
+			slog.ErrorContext(ctx, "error getting secret", "secret_name", config.AuthConfig.SecretName, "error", err)
+			panic(err)
+		}
+
+		config.AuthConfig.Secret = authSecret
+	}
+
+	if !config.AuthConfig.IsSecretSet() && !config.AuthConfig.IsJwksSet() {
+		err := errors.New("no secret or jwks url configured to verify the tokens")
+		slog.ErrorContext(ctx, "error loading environment", "error", err)
+		panic(err)
+	}
+
+	if config.EncryptionConfig.IsKeysSecretNameSet() {
+		encryptionKeys, err := secret.GetSecret(ctx, config.EncryptionConfig.KeysSecretName)
+		if err != nil {
+			slog.ErrorContext(ctx, "error getting secret", "secret_name", config.EncryptionConfig.KeysSecretName, "error", err)
+			panic(err)
+		}
+
+		config.EncryptionConfig.Keys = encryptionKeys
+	}
+
+	if !config.EncryptionConfig.IsKeysSet() {
+		err := errors.New("no keys configured to encrypt the deletion requests")
+		slog.ErrorContext(ctx, "error loading environment", "error", err)
+		panic(err)
+	}
+
 	server := server.NewServer(config)
 
+	if err := server.DatabaseService.WaitUntilReady(ctx); err != nil {
+		slog.ErrorContext(ctx, "error connecting to the database", "error", err)
+		panic(err)
+	}
+
+	if config.DbConfig.MigrateOnStartup {
+		migrator, err := newMigrator(server.DatabaseService.GetInstance())
+		if err != nil {
+			slog.ErrorContext(ctx, "error loading the migrations", "error", err)
+			panic(err)
+		}
+
+		if err := migrateUp(ctx, migrator); err != nil {
+			slog.ErrorContext(ctx, "error migrating the database", "error", err)
+			panic(err)
+		}
+	}
+
+	if err := server.DeletionTopicService.UpdateTopicArn(ctx); err != nil {
+		slog.ErrorContext(ctx, "error updating topic arn", "topic_name", server.DeletionTopicService.GetTopicName(), "error", err)
+		panic(err)
+	}
+
 	httpServer := server.GetHttpServer()
+	metricsServer := server.GetMetricsServer()
+
+	deletionWorker := server.GetDeletionWorker()
+	deletionWorker.Start(ctx)
+
+	outboxRelayWorker := server.GetOutboxRelayWorker()
+	outboxRelayWorker.Start(ctx)
+
+	outboxCleanupWorker := server.GetOutboxCleanupWorker()
+	outboxCleanupWorker.Start(ctx)
+
+	reencryptionWorker := server.GetReencryptionWorker()
+	reencryptionWorker.Start(ctx)
+
+	consumerCtx, stopConsumers := context.WithCancel(ctx)
+	defer stopConsumers()
+
+	var consumers sync.WaitGroup
+
+	if server.DeletionQueueService != nil {
+		if err := server.DeletionQueueService.UpdateQueueUrl(ctx); err != nil {
+			slog.ErrorContext(ctx, "error updating queue url", "queue_name", server.DeletionQueueService.GetQueueName(), "error", err)
+			panic(err)
+		}
+
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			server.DeletionQueueService.ConsumeMessages(consumerCtx)
+		}()
+	}
+
+	if config.PasswordConfig.MigratePlaintext {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			migrated, err := server.Dependency.CredentialsService.MigratePlaintextPasswords(consumerCtx)
+			if err != nil {
+				slog.ErrorContext(ctx, "error migrating plaintext passwords", "migrated", migrated, "error", err)
+				return
+			}
+			slog.InfoContext(ctx, "plaintext passwords migrated", "migrated", migrated)
+		}()
+	}
 
 	go func() {
-		slog.InfoContext(ctx, "🚀 Server started", "address", httpServer.Addr)
+		slog.InfoContext(ctx, "🚀 Server started", "addr", httpServer.Addr)
 		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			slog.ErrorContext(ctx, "http server error", "error", err)
 			panic(err)
@@ -81,6 +215,15 @@ func main() {
 		slog.InfoContext(ctx, "http server stopped serving requests")
 	}()
 
+	go func() {
+		slog.InfoContext(ctx, "metrics server started", "addr", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			slog.ErrorContext(ctx, "metrics server error", "error", err)
+			panic(err)
+		}
+		slog.InfoContext(ctx, "metrics server stopped serving requests")
+	}()
+
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	<-sc
@@ -91,5 +234,37 @@ func main() {
 	if err := httpServer.Shutdown(ctx); err != nil {
 		slog.ErrorContext(ctx, "error while trying to shutdown the server", "error", err)
 	}
+
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to shutdown the metrics server", "error", err)
+	}
+
+	stopConsumers()
+	consumers.Wait()
+
+	if err := deletionWorker.Stop(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to stop the deletion worker", "error", err)
+	}
+
+	if err := outboxRelayWorker.Stop(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to stop the outbox relay worker", "error", err)
+	}
+
+	if err := outboxCleanupWorker.Stop(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to stop the outbox cleanup worker", "error", err)
+	}
+
+	if err := reencryptionWorker.Stop(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to stop the reencryption worker", "error", err)
+	}
+
+	if err := server.DatabaseService.Close(); err != nil {
+		slog.ErrorContext(ctx, "error while trying to close the database connections", "error", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while trying to flush the spans", "error", err)
+	}
+
 	slog.InfoContext(ctx, "graceful shutdown completed ✅")
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database/migration"
+)
+
+const migrateUsage = "usage: api [local] migrate up|down|status"
+
+func newMigrator(conn *sql.DB) (migration.Migrator, error) {
+	migrations, err := migration.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return migration.NewMigrator(conn, migrations), nil
+}
+
+func migrateUp(ctx context.Context, migrator migration.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		slog.InfoContext(ctx, "migration applied", "version", migration.Version, "migration", migration.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "database migrated", "applied", len(applied))
+
+	return nil
+}
+
+func runMigrateCommand(ctx context.Context, conn *sql.DB, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := newMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return migrateUp(ctx, migrator)
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+
+		if reverted == nil {
+			slog.InfoContext(ctx, "no migration to revert")
+			return nil
+		}
+
+		slog.InfoContext(ctx, "migration reverted", "version", reverted.Version, "migration", reverted.Name)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.IsApplied() {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(writer, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return writer.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
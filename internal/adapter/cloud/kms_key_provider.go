@@ -0,0 +1,83 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+)
+
+const keyVersionContext = "key_version"
+
+var ErrKmsKeyNotFound = errors.New("no kms key configured for the key version")
+
+type AwsKmsKeyProvider struct {
+	KeyIds  map[int]string
+	Version int
+	Client  *kms.Client
+}
+
+// NewKmsKeyProvider generates and unwraps the data keys with the KMS key of
+// each version, the key version is sent as the encryption context so a data
+// key cannot be unwrapped as if it were of another version.
+func NewKmsKeyProvider(config aws.Config, keyIds map[int]string, currentVersion int) (*AwsKmsKeyProvider, error) {
+	if _, exists := keyIds[currentVersion]; !exists {
+		return nil, fmt.Errorf("%w: %d", ErrKmsKeyNotFound, currentVersion)
+	}
+
+	return &AwsKmsKeyProvider{
+		KeyIds:  keyIds,
+		Version: currentVersion,
+		Client:  kms.NewFromConfig(config),
+	}, nil
+}
+
+func (p *AwsKmsKeyProvider) CurrentVersion() int {
+	return p.Version
+}
+
+func (p *AwsKmsKeyProvider) GenerateDataKey(ctx context.Context) (provider.DataKey, error) {
+	output, err := p.Client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:             aws.String(p.KeyIds[p.Version]),
+		KeySpec:           types.DataKeySpecAes256,
+		EncryptionContext: encryptionContext(p.Version),
+	})
+	if err != nil {
+		return provider.DataKey{}, err
+	}
+
+	return provider.DataKey{
+		Plaintext: output.Plaintext,
+		Wrapped:   output.CiphertextBlob,
+		Version:   p.Version,
+	}, nil
+}
+
+func (p *AwsKmsKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte, version int) ([]byte, error) {
+	keyId, exists := p.KeyIds[version]
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrKmsKeyNotFound, version)
+	}
+
+	output, err := p.Client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(keyId),
+		CiphertextBlob:    wrapped,
+		EncryptionContext: encryptionContext(version),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Plaintext, nil
+}
+
+func encryptionContext(version int) map[string]string {
+	return map[string]string{
+		keyVersionContext: strconv.Itoa(version),
+	}
+}
@@ -0,0 +1,163 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKmsKeyProvider(t *testing.T) {
+	t.Run("Should return error if the current version has no key", func(t *testing.T) {
+		// Arrange
+		stubber := testtools.NewStubber()
+
+		// Act
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1"}, 2)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrKmsKeyNotFound)
+		assert.Nil(t, provider)
+	})
+}
+
+func TestKmsKeyProvider_GenerateDataKey(t *testing.T) {
+	t.Run("Should generate a data key with the key of the current version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GenerateDataKey",
+			Input: &kms.GenerateDataKeyInput{
+				KeyId:             aws.String("alias/key-2"),
+				KeySpec:           types.DataKeySpecAes256,
+				EncryptionContext: map[string]string{"key_version": "2"},
+			},
+			Output: &kms.GenerateDataKeyOutput{
+				Plaintext:      []byte("plaintext"),
+				CiphertextBlob: []byte("wrapped"),
+			},
+		})
+
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1", 2: "alias/key-2"}, 2)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.GenerateDataKey(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.CurrentVersion())
+		assert.Equal(t, []byte("plaintext"), res.Plaintext)
+		assert.Equal(t, []byte("wrapped"), res.Wrapped)
+		assert.Equal(t, 2, res.Version)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the data key could not be generated", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GenerateDataKey",
+			Input: &kms.GenerateDataKeyInput{
+				KeyId:             aws.String("alias/key-1"),
+				KeySpec:           types.DataKeySpecAes256,
+				EncryptionContext: map[string]string{"key_version": "1"},
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1"}, 1)
+		assert.NoError(t, err)
+
+		// Act
+		_, err = provider.GenerateDataKey(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestKmsKeyProvider_DecryptDataKey(t *testing.T) {
+	t.Run("Should decrypt the data key with the key of its version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Decrypt",
+			Input: &kms.DecryptInput{
+				KeyId:             aws.String("alias/key-1"),
+				CiphertextBlob:    []byte("wrapped"),
+				EncryptionContext: map[string]string{"key_version": "1"},
+			},
+			Output: &kms.DecryptOutput{
+				Plaintext: []byte("plaintext"),
+			},
+		})
+
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1", 2: "alias/key-2"}, 2)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, []byte("wrapped"), 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("plaintext"), res)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the version has no key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1"}, 1)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, []byte("wrapped"), 3)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrKmsKeyNotFound)
+		assert.Nil(t, res)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the data key could not be decrypted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Decrypt",
+			Input: &kms.DecryptInput{
+				KeyId:             aws.String("alias/key-1"),
+				CiphertextBlob:    []byte("wrapped"),
+				EncryptionContext: map[string]string{"key_version": "1"},
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		provider, err := NewKmsKeyProvider(*stubber.SdkConfig, map[int]string{1: "alias/key-1"}, 1)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, []byte("wrapped"), 1)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		testtools.ExitTest(stubber, t)
+	})
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMessageProcessor is an autogenerated mock type for the MessageProcessor type
+type MockMessageProcessor struct {
+	mock.Mock
+}
+
+// ProcessMessage provides a mock function with given fields: ctx, message
+func (_m *MockMessageProcessor) ProcessMessage(ctx context.Context, message string) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockMessageProcessor creates a new instance of MockMessageProcessor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMessageProcessor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMessageProcessor {
+	mock := &MockMessageProcessor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	health "github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,6 +37,26 @@ func (_m *MockQueueService) GetQueueName() string {
 	return r0
 }
 
+// Health provides a mock function with given fields: ctx
+func (_m *MockQueueService) Health(ctx context.Context) *health.HealthStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 *health.HealthStatus
+	if rf, ok := ret.Get(0).(func(context.Context) *health.HealthStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.HealthStatus)
+		}
+	}
+
+	return r0
+}
+
 // UpdateQueueUrl provides a mock function with given fields: ctx
 func (_m *MockQueueService) UpdateQueueUrl(ctx context.Context) error {
 	ret := _m.Called(ctx)
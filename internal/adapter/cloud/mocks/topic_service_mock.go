@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	health "github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +32,26 @@ func (_m *MockTopicService) GetTopicName() string {
 	return r0
 }
 
+// Health provides a mock function with given fields: ctx
+func (_m *MockTopicService) Health(ctx context.Context) *health.HealthStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 *health.HealthStatus
+	if rf, ok := ret.Get(0).(func(context.Context) *health.HealthStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.HealthStatus)
+		}
+	}
+
+	return r0
+}
+
 // PublishMessage provides a mock function with given fields: ctx, message
 func (_m *MockTopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
 	ret := _m.Called(ctx, message)
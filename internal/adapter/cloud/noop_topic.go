@@ -0,0 +1,33 @@
+package cloud
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+// NoopTopicService is used when no topic is configured, so the services can
+// publish their events without checking if there is someone to receive them.
+type NoopTopicService struct{}
+
+func NewNoopTopicService() TopicService {
+	return &NoopTopicService{}
+}
+
+func (s *NoopTopicService) GetTopicName() string {
+	return ""
+}
+
+func (s *NoopTopicService) UpdateTopicArn(ctx context.Context) error {
+	return nil
+}
+
+func (s *NoopTopicService) Health(ctx context.Context) *health.HealthStatus {
+	return health.Healthy()
+}
+
+func (s *NoopTopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
+	slog.DebugContext(ctx, "no topic configured, message discarded")
+	return nil, nil
+}
@@ -0,0 +1,18 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+type QueueService interface {
+	GetQueueName() string
+	UpdateQueueUrl(ctx context.Context) error
+	ConsumeMessages(ctx context.Context)
+	health.HealthCheck
+}
+
+type MessageProcessor interface {
+	ProcessMessage(ctx context.Context, message string) error
+}
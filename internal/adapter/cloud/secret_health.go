@@ -0,0 +1,34 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+// AwsSecretHealthCheck checks the secret can still be described, without
+// reading its value, so the permissions of the service are verified too.
+type AwsSecretHealthCheck struct {
+	SecretName string
+	Client     *secretsmanager.Client
+}
+
+func NewSecretHealthCheck(secretName string, config aws.Config) health.HealthCheck {
+	return &AwsSecretHealthCheck{
+		SecretName: secretName,
+		Client:     secretsmanager.NewFromConfig(config),
+	}
+}
+
+func (s *AwsSecretHealthCheck) Health(ctx context.Context) *health.HealthStatus {
+	_, err := s.Client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(s.SecretName),
+	})
+	if err != nil {
+		return health.Unhealthy(err)
+	}
+
+	return health.Healthy()
+}
@@ -0,0 +1,61 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretHealth(t *testing.T) {
+	t.Run("Should return healthy when the secret can be described", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DescribeSecret",
+			Input: &secretsmanager.DescribeSecretInput{
+				SecretId: aws.String("my-secret"),
+			},
+			Output: &secretsmanager.DescribeSecretOutput{},
+		})
+
+		check := NewSecretHealthCheck("my-secret", *stubber.SdkConfig)
+
+		// Act
+		res := check.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "healthy", res.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when the secret cannot be described", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DescribeSecret",
+			Input: &secretsmanager.DescribeSecretInput{
+				SecretId: aws.String("my-secret"),
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		check := NewSecretHealthCheck("my-secret", *stubber.SdkConfig)
+
+		// Act
+		res := check.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "unhealthy", res.Status)
+		assert.True(t, res.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
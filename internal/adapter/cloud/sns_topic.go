@@ -0,0 +1,94 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+type AwsSnsTopicService struct {
+	TopicName string
+	TopicArn  string
+	Client    *sns.Client
+}
+
+func NewTopicService(topicName string, config aws.Config) TopicService {
+	return &AwsSnsTopicService{
+		TopicName: topicName,
+		Client:    sns.NewFromConfig(config),
+	}
+}
+
+func (s *AwsSnsTopicService) GetTopicName() string {
+	return s.TopicName
+}
+
+// UpdateTopicArn looks for the topic by its name, since SNS has no way to get
+// the arn of a topic without listing them.
+func (s *AwsSnsTopicService) UpdateTopicArn(ctx context.Context) error {
+	paginator := sns.NewListTopicsPaginator(s.Client, &sns.ListTopicsInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, topic := range output.Topics {
+			if strings.HasSuffix(aws.ToString(topic.TopicArn), ":"+s.TopicName) {
+				s.TopicArn = aws.ToString(topic.TopicArn)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("topic %s not found", s.TopicName)
+}
+
+// Health checks the topic can still be reached with the resolved arn.
+func (s *AwsSnsTopicService) Health(ctx context.Context) *health.HealthStatus {
+	_, err := s.Client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(s.TopicArn),
+	})
+	if err != nil {
+		return health.Unhealthy(err)
+	}
+
+	return health.Healthy()
+}
+
+func (s *AwsSnsTopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &sns.PublishInput{
+		TopicArn: aws.String(s.TopicArn),
+		Message:  aws.String(string(body)),
+	}
+
+	if message, ok := message.(MessageWithAttributes); ok {
+		input.MessageAttributes = make(map[string]types.MessageAttributeValue)
+
+		for key, value := range message.GetMessageAttributes() {
+			input.MessageAttributes[key] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(value),
+			}
+		}
+	}
+
+	output, err := s.Client.Publish(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.MessageId, nil
+}
@@ -0,0 +1,267 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/stretchr/testify/assert"
+)
+
+type message struct {
+	Value string `json:"value"`
+}
+
+type messageWithAttributes struct {
+	Value string `json:"value"`
+}
+
+func (m messageWithAttributes) GetMessageAttributes() map[string]string {
+	return map[string]string{
+		"event_type": "MyEvent",
+	}
+}
+
+func TestUpdateTopicArn(t *testing.T) {
+	t.Run("Should update the topic arn", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ListTopics",
+			Input:         &sns.ListTopicsInput{},
+			Output: &sns.ListTopicsOutput{
+				Topics: []types.Topic{
+					{TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic-other")},
+				},
+				NextToken: aws.String("next"),
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ListTopics",
+			Input: &sns.ListTopicsInput{
+				NextToken: aws.String("next"),
+			},
+			Output: &sns.ListTopicsOutput{
+				Topics: []types.Topic{
+					{TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic")},
+				},
+			},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig).(*AwsSnsTopicService)
+
+		// Act
+		err := service.UpdateTopicArn(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "my-topic", service.GetTopicName())
+		assert.Equal(t, "arn:aws:sns:us-east-1:000000000000:my-topic", service.TopicArn)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the topic does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ListTopics",
+			Input:         &sns.ListTopicsInput{},
+			Output:        &sns.ListTopicsOutput{},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig)
+
+		// Act
+		err := service.UpdateTopicArn(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the topics could not be listed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ListTopics",
+			Input:         &sns.ListTopicsInput{},
+			Error:         &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig)
+
+		// Act
+		err := service.UpdateTopicArn(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestPublishMessage(t *testing.T) {
+	t.Run("Should publish a message", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic"),
+				Message:  aws.String(`{"value":"my-value"}`),
+			},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("message-id"),
+			},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig).(*AwsSnsTopicService)
+		service.TopicArn = "arn:aws:sns:us-east-1:000000000000:my-topic"
+
+		// Act
+		messageId, err := service.PublishMessage(ctx, message{Value: "my-value"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "message-id", *messageId)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish a message with attributes", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic"),
+				Message:  aws.String(`{"value":"my-value"}`),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"event_type": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("MyEvent"),
+					},
+				},
+			},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("message-id"),
+			},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig).(*AwsSnsTopicService)
+		service.TopicArn = "arn:aws:sns:us-east-1:000000000000:my-topic"
+
+		// Act
+		messageId, err := service.PublishMessage(ctx, messageWithAttributes{Value: "my-value"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "message-id", *messageId)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the message could not be published", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String(""),
+				Message:  aws.String(`{"value":"my-value"}`),
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig)
+
+		// Act
+		messageId, err := service.PublishMessage(ctx, message{Value: "my-value"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, messageId)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the message could not be serialized", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig)
+
+		// Act
+		messageId, err := service.PublishMessage(ctx, make(chan int))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, messageId)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestTopicHealth(t *testing.T) {
+	t.Run("Should return healthy when the topic is reachable", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetTopicAttributes",
+			Input: &sns.GetTopicAttributesInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic"),
+			},
+			Output: &sns.GetTopicAttributesOutput{},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig).(*AwsSnsTopicService)
+		service.TopicArn = "arn:aws:sns:us-east-1:000000000000:my-topic"
+
+		// Act
+		res := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "healthy", res.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when the topic is not reachable", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetTopicAttributes",
+			Input: &sns.GetTopicAttributesInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:000000000000:my-topic"),
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		service := NewTopicService("my-topic", *stubber.SdkConfig).(*AwsSnsTopicService)
+		service.TopicArn = "arn:aws:sns:us-east-1:000000000000:my-topic"
+
+		// Act
+		res := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "unhealthy", res.Status)
+		assert.True(t, res.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
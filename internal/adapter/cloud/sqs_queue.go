@@ -0,0 +1,259 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+const (
+	receiveErrorBackoff = 5 * time.Second
+)
+
+type AwsSqsQueueService struct {
+	QueueName string
+	QueueUrl  string
+	Client    *sqs.Client
+
+	config    *environment.CloudConfig
+	processor MessageProcessor
+
+	deadLetterQueueArn string
+	maxReceiveCount    int32
+}
+
+func NewQueueService(
+	queueName string,
+	awsConfig aws.Config,
+	config *environment.CloudConfig,
+	processor MessageProcessor,
+) QueueService {
+	return &AwsSqsQueueService{
+		QueueName: queueName,
+		Client:    sqs.NewFromConfig(awsConfig),
+
+		config:    config,
+		processor: processor,
+
+		maxReceiveCount: config.QueueMaxReceiveCount,
+	}
+}
+
+func (s *AwsSqsQueueService) GetQueueName() string {
+	return s.QueueName
+}
+
+// UpdateQueueUrl resolves the url of the queue by its name and reads its
+// redrive policy, so the consumer knows when a message is about to be moved
+// to the dead letter queue.
+func (s *AwsSqsQueueService) UpdateQueueUrl(ctx context.Context) error {
+	output, err := s.Client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName: aws.String(s.QueueName),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.QueueUrl = *output.QueueUrl
+
+	attributes, err := s.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(s.QueueUrl),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameRedrivePolicy,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	redrivePolicy, ok := attributes.Attributes[string(types.QueueAttributeNameRedrivePolicy)]
+	if !ok {
+		slog.WarnContext(ctx, "queue has no dead letter queue configured", "queue_name", s.QueueName)
+		return nil
+	}
+
+	deadLetterQueueArn, maxReceiveCount, err := parseRedrivePolicy(redrivePolicy)
+	if err != nil {
+		return err
+	}
+
+	s.deadLetterQueueArn = deadLetterQueueArn
+	s.maxReceiveCount = maxReceiveCount
+
+	return nil
+}
+
+// Health checks the queue can still be reached with the resolved url.
+func (s *AwsSqsQueueService) Health(ctx context.Context) *health.HealthStatus {
+	_, err := s.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(s.QueueUrl),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameApproximateNumberOfMessages,
+		},
+	})
+	if err != nil {
+		return health.Unhealthy(err)
+	}
+
+	return health.Healthy()
+}
+
+func (s *AwsSqsQueueService) ConsumeMessages(ctx context.Context) {
+	slog.InfoContext(ctx, "consuming messages", "queue_name", s.QueueName)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "stopped consuming messages", "queue_name", s.QueueName)
+			return
+		default:
+		}
+
+		if err := s.receiveMessages(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "error receiving messages", "queue_name", s.QueueName, "error", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(receiveErrorBackoff):
+			}
+		}
+	}
+}
+
+func (s *AwsSqsQueueService) receiveMessages(ctx context.Context) error {
+	output, err := s.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(s.QueueUrl),
+		MaxNumberOfMessages: s.config.QueueMaxMessages,
+		WaitTimeSeconds:     s.config.QueueWaitTimeSeconds,
+		VisibilityTimeout:   s.config.QueueVisibilityTimeoutSeconds,
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+			types.MessageSystemAttributeNameApproximateReceiveCount,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	processed := make([]types.Message, 0, len(output.Messages))
+
+	for _, message := range output.Messages {
+		if s.processMessage(ctx, message) {
+			processed = append(processed, message)
+		}
+	}
+
+	return s.deleteMessages(ctx, processed)
+}
+
+// processMessage reports whether the message can be removed from the queue.
+// Failed messages are left to become visible again, until the redrive policy
+// moves them to the dead letter queue. When there is no dead letter queue the
+// message is dropped after the max receive count, to avoid a poison message.
+func (s *AwsSqsQueueService) processMessage(ctx context.Context, message types.Message) bool {
+	err := s.processor.ProcessMessage(ctx, aws.ToString(message.Body))
+	if err == nil {
+		return true
+	}
+
+	receiveCount := getReceiveCount(message)
+
+	logger := slog.With("queue_name", s.QueueName,
+		"message_id", aws.ToString(message.MessageId),
+		"receive_count", receiveCount,
+		"error", err)
+
+	if receiveCount < s.maxReceiveCount {
+		logger.WarnContext(ctx, "error processing message, it will be retried")
+		return false
+	}
+
+	if s.deadLetterQueueArn != "" {
+		logger.ErrorContext(ctx, "error processing message, it will be moved to the dead letter queue", "dead_letter_queue_arn", s.deadLetterQueueArn)
+		return false
+	}
+
+	logger.ErrorContext(ctx, "error processing message, no dead letter queue configured, discarding it")
+	return true
+}
+
+func (s *AwsSqsQueueService) deleteMessages(ctx context.Context, messages []types.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	entries := make([]types.DeleteMessageBatchRequestEntry, 0, len(messages))
+
+	for i, message := range messages {
+		entries = append(entries, types.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: message.ReceiptHandle,
+		})
+	}
+
+	output, err := s.Client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(s.QueueUrl),
+		Entries:  entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, failed := range output.Failed {
+		slog.ErrorContext(ctx, "error deleting message",
+			"queue_name", s.QueueName,
+			"entry_id", aws.ToString(failed.Id),
+			"code", aws.ToString(failed.Code),
+			"error", aws.ToString(failed.Message))
+	}
+
+	return nil
+}
+
+func getReceiveCount(message types.Message) int32 {
+	value, ok := message.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)]
+	if !ok {
+		return 0
+	}
+
+	count, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0
+	}
+
+	return int32(count)
+}
+
+type redrivePolicy struct {
+	DeadLetterTargetArn string          `json:"deadLetterTargetArn"`
+	MaxReceiveCount     json.RawMessage `json:"maxReceiveCount"`
+}
+
+func parseRedrivePolicy(value string) (string, int32, error) {
+	var policy redrivePolicy
+	if err := json.Unmarshal([]byte(value), &policy); err != nil {
+		return "", 0, fmt.Errorf("invalid redrive policy: %w", err)
+	}
+
+	if policy.DeadLetterTargetArn == "" {
+		return "", 0, errors.New("invalid redrive policy: dead letter target arn is empty")
+	}
+
+	// the max receive count may come as a number or as a string
+	maxReceiveCount, err := strconv.ParseInt(strings.Trim(string(policy.MaxReceiveCount), `"`), 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid redrive policy: %w", err)
+	}
+
+	return policy.DeadLetterTargetArn, int32(maxReceiveCount), nil
+}
@@ -0,0 +1,455 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var queueConfig = &environment.CloudConfig{
+	QueueWaitTimeSeconds:          20,
+	QueueVisibilityTimeoutSeconds: 30,
+	QueueMaxMessages:              10,
+	QueueMaxReceiveCount:          3,
+}
+
+func newReceiveMessageStub(messages ...types.Message) testtools.Stub {
+	return testtools.Stub{
+		OperationName: "ReceiveMessage",
+		Input: &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String("http://localhost/queue"),
+			MaxNumberOfMessages: 10,
+			WaitTimeSeconds:     20,
+			VisibilityTimeout:   30,
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+				types.MessageSystemAttributeNameApproximateReceiveCount,
+			},
+		},
+		Output: &sqs.ReceiveMessageOutput{
+			Messages: messages,
+		},
+	}
+}
+
+func newMessage(id string, receiveCount string) types.Message {
+	return types.Message{
+		MessageId:     aws.String(id),
+		Body:          aws.String("body-" + id),
+		ReceiptHandle: aws.String("receipt-" + id),
+		Attributes: map[string]string{
+			"ApproximateReceiveCount": receiveCount,
+		},
+	}
+}
+
+func TestUpdateQueueUrl(t *testing.T) {
+	t.Run("Should update the queue url and the redrive policy", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("my-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("http://localhost/queue"),
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameRedrivePolicy,
+				},
+			},
+			Output: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:000000000000:my-queue-dlq","maxReceiveCount":"7"}`,
+				},
+			},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "my-queue", service.GetQueueName())
+		assert.Equal(t, "http://localhost/queue", service.QueueUrl)
+		assert.Equal(t, "arn:aws:sqs:us-east-1:000000000000:my-queue-dlq", service.deadLetterQueueArn)
+		assert.Equal(t, int32(7), service.maxReceiveCount)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should keep the configured max receive count when there is no redrive policy", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("my-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("http://localhost/queue"),
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameRedrivePolicy,
+				},
+			},
+			Output: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{},
+			},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, service.deadLetterQueueArn)
+		assert.Equal(t, int32(3), service.maxReceiveCount)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the redrive policy is not valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("my-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("http://localhost/queue"),
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameRedrivePolicy,
+				},
+			},
+			Output: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					"RedrivePolicy": `{"maxReceiveCount":5}`,
+				},
+			},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the queue does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("my-queue"),
+			},
+			Error: raiseErr,
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestReceiveMessages(t *testing.T) {
+	t.Run("Should delete the processed messages in batch", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(newReceiveMessageStub(newMessage("1", "1"), newMessage("2", "1")))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessageBatch",
+			Input: &sqs.DeleteMessageBatchInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				Entries: []types.DeleteMessageBatchRequestEntry{
+					{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-1")},
+					{Id: aws.String("1"), ReceiptHandle: aws.String("receipt-2")},
+				},
+			},
+			Output: &sqs.DeleteMessageBatchOutput{},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+		processor.On("ProcessMessage", mock.Anything, "body-1").Return(nil)
+		processor.On("ProcessMessage", mock.Anything, "body-2").Return(nil)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		err := service.receiveMessages(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		processor.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should keep the failed messages in the queue to be retried", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(newReceiveMessageStub(newMessage("1", "1"), newMessage("2", "2")))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessageBatch",
+			Input: &sqs.DeleteMessageBatchInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				Entries: []types.DeleteMessageBatchRequestEntry{
+					{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-2")},
+				},
+			},
+			Output: &sqs.DeleteMessageBatchOutput{
+				Failed: []types.BatchResultErrorEntry{
+					{Id: aws.String("0"), Code: aws.String("ReceiptHandleIsInvalid"), Message: aws.String("error")},
+				},
+			},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+		processor.On("ProcessMessage", mock.Anything, "body-1").Return(errors.New("error"))
+		processor.On("ProcessMessage", mock.Anything, "body-2").Return(nil)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		err := service.receiveMessages(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		processor.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should leave a failed message to the dead letter queue", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(newReceiveMessageStub(newMessage("1", "3")))
+
+		processor := mocks.NewMockMessageProcessor(t)
+		processor.On("ProcessMessage", mock.Anything, "body-1").Return(errors.New("error"))
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+		service.deadLetterQueueArn = "arn:aws:sqs:us-east-1:000000000000:my-queue-dlq"
+
+		// Act
+		err := service.receiveMessages(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		processor.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should discard a poison message when there is no dead letter queue", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(newReceiveMessageStub(newMessage("1", "3")))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessageBatch",
+			Input: &sqs.DeleteMessageBatchInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				Entries: []types.DeleteMessageBatchRequestEntry{
+					{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-1")},
+				},
+			},
+			Output: &sqs.DeleteMessageBatchOutput{},
+		})
+
+		processor := mocks.NewMockMessageProcessor(t)
+		processor.On("ProcessMessage", mock.Anything, "body-1").Return(errors.New("error"))
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		err := service.receiveMessages(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		processor.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error if the messages could not be received", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stub := newReceiveMessageStub()
+		stub.Output = nil
+		stub.Error = &testtools.StubError{Err: errors.New("ClientError")}
+		stubber.Add(stub)
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		err := service.receiveMessages(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestConsumeMessages(t *testing.T) {
+	t.Run("Should stop consuming when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		stubber := testtools.NewStubber()
+
+		stubber.Add(newReceiveMessageStub())
+
+		processor := mocks.NewMockMessageProcessor(t)
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, processor).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		done := make(chan struct{})
+
+		// Act
+		go func() {
+			defer close(done)
+			service.ConsumeMessages(ctx)
+		}()
+
+		cancel()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("consumer did not stop")
+		}
+	})
+}
+
+func TestQueueHealth(t *testing.T) {
+	t.Run("Should return healthy when the queue is reachable", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameApproximateNumberOfMessages,
+				},
+			},
+			Output: &sqs.GetQueueAttributesOutput{},
+		})
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, mocks.NewMockMessageProcessor(t)).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		res := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "healthy", res.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when the queue is not reachable", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String("http://localhost/queue"),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameApproximateNumberOfMessages,
+				},
+			},
+			Error: &testtools.StubError{Err: errors.New("ClientError")},
+		})
+
+		service := NewQueueService("my-queue", *stubber.SdkConfig, queueConfig, mocks.NewMockMessageProcessor(t)).(*AwsSqsQueueService)
+		service.QueueUrl = "http://localhost/queue"
+
+		// Act
+		res := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, "unhealthy", res.Status)
+		assert.True(t, res.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
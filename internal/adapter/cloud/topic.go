@@ -0,0 +1,20 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+)
+
+type TopicService interface {
+	GetTopicName() string
+	UpdateTopicArn(ctx context.Context) error
+	PublishMessage(ctx context.Context, message interface{}) (*string, error)
+	health.HealthCheck
+}
+
+// MessageWithAttributes is implemented by the messages that need to be
+// published with attributes, allowing the subscribers to filter them.
+type MessageWithAttributes interface {
+	GetMessageAttributes() map[string]string
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/backoff"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 
 	_ "github.com/lib/pq"
@@ -15,11 +16,16 @@ import (
 
 type DatabaseService interface {
 	GetInstance() *sql.DB
+	// WaitUntilReady pings the database until it answers, backing off between
+	// the attempts, so a bad url fails the startup instead of the requests.
+	WaitUntilReady(ctx context.Context) error
+	Close() error
 	health.HealthCheck
 }
 
 type Service struct {
 	Client *sql.DB
+	config *environment.DatabaseConfig
 }
 
 func NewDatabase(config *environment.Config) DatabaseService {
@@ -28,8 +34,14 @@ func NewDatabase(config *environment.Config) DatabaseService {
 		panic(fmt.Errorf("error on connect to database: %v", err))
 	}
 
+	client.SetMaxOpenConns(config.DbConfig.MaxOpenConns)
+	client.SetMaxIdleConns(config.DbConfig.MaxIdleConns)
+	client.SetConnMaxLifetime(config.DbConfig.ConnMaxLifetime)
+	client.SetConnMaxIdleTime(config.DbConfig.ConnMaxIdleTime)
+
 	return &Service{
 		Client: client,
+		config: config.DbConfig,
 	}
 }
 
@@ -37,19 +49,53 @@ func (s *Service) GetInstance() *sql.DB {
 	return s.Client
 }
 
-func (s *Service) Health() *health.HealthStatus {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
+func (s *Service) WaitUntilReady(ctx context.Context) error {
+	attempts := max(s.config.ConnectMaxAttempts, 1)
 
-	if err := s.Client.PingContext(ctx); err != nil {
-		slog.Error("could not ping the database", "error", err)
-		return &health.HealthStatus{
-			Status: "unhealthy",
-			Err:    err.Error(),
+	var err error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = s.ping(ctx); err == nil {
+			return nil
+		}
+
+		if attempt == attempts-1 {
+			break
+		}
+
+		wait := backoff.Exponential(s.config.ConnectRetryBackoff, s.config.ConnectMaxRetryBackoff, attempt)
+
+		slog.WarnContext(ctx, "database is not ready, retrying", "attempt", attempt+1, "max_attempts", attempts, "retry_in", wait, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
 		}
 	}
 
-	return &health.HealthStatus{
-		Status: "healthy",
+	return fmt.Errorf("database not ready after %d attempts: %w", attempts, err)
+}
+
+func (s *Service) ping(ctx context.Context) error {
+	if s.config.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.ConnectTimeout)
+		defer cancel()
 	}
+
+	return s.Client.PingContext(ctx)
+}
+
+func (s *Service) Close() error {
+	return s.Client.Close()
+}
+
+func (s *Service) Health(ctx context.Context) *health.HealthStatus {
+	if err := s.Client.PingContext(ctx); err != nil {
+		slog.ErrorContext(ctx, "could not ping the database", "error", err)
+		return health.Unhealthy(err)
+	}
+
+	return health.Healthy()
 }
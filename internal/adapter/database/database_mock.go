@@ -3,6 +3,8 @@
 package database
 
 import (
+	context "context"
+
 	health "github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,6 +16,24 @@ type MockDatabaseService struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *MockDatabaseService) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetInstance provides a mock function with given fields:
 func (_m *MockDatabaseService) GetInstance() *sql.DB {
 	ret := _m.Called()
@@ -34,17 +54,17 @@ func (_m *MockDatabaseService) GetInstance() *sql.DB {
 	return r0
 }
 
-// Health provides a mock function with given fields:
-func (_m *MockDatabaseService) Health() *health.HealthStatus {
-	ret := _m.Called()
+// Health provides a mock function with given fields: ctx
+func (_m *MockDatabaseService) Health(ctx context.Context) *health.HealthStatus {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Health")
 	}
 
 	var r0 *health.HealthStatus
-	if rf, ok := ret.Get(0).(func() *health.HealthStatus); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *health.HealthStatus); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*health.HealthStatus)
@@ -54,6 +74,24 @@ func (_m *MockDatabaseService) Health() *health.HealthStatus {
 	return r0
 }
 
+// WaitUntilReady provides a mock function with given fields: ctx
+func (_m *MockDatabaseService) WaitUntilReady(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitUntilReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockDatabaseService creates a new instance of MockDatabaseService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDatabaseService(t interface {
@@ -1,7 +1,10 @@
 package database_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
@@ -54,7 +57,7 @@ func TestHealth(t *testing.T) {
 		service.(*database.Service).Client = db
 
 		// Act
-		res := service.Health()
+		res := service.Health(context.Background())
 
 		// Assert
 		assert.NotNil(mt, res)
@@ -77,11 +80,118 @@ func TestHealth(t *testing.T) {
 
 		service := database.NewDatabase(config)
 
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
 		// Act
-		res := service.Health()
+		res := service.Health(ctx)
 
 		// Assert
 		assert.NotNil(mt, res)
 		assert.Equal(mt, "unhealthy", res.Status)
 	})
 }
+
+func newService(t *testing.T, maxAttempts int) (database.DatabaseService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	config := &environment.Config{
+		DbConfig: &environment.DatabaseConfig{
+			Url:                    "postgres://host:1234",
+			ConnectTimeout:         time.Second,
+			ConnectMaxAttempts:     maxAttempts,
+			ConnectRetryBackoff:    time.Millisecond,
+			ConnectMaxRetryBackoff: 2 * time.Millisecond,
+		},
+	}
+
+	service := database.NewDatabase(config)
+	service.(*database.Service).Client = db
+
+	return service, mock
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	t.Run("Should return when the database answers", func(t *testing.T) {
+		// Arrange
+		service, mock := newService(t, 3)
+
+		mock.ExpectPing()
+
+		// Act
+		err := service.WaitUntilReady(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should retry until the database answers", func(t *testing.T) {
+		// Arrange
+		service, mock := newService(t, 3)
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing()
+
+		// Act
+		err := service.WaitUntilReady(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error after the max attempts", func(t *testing.T) {
+		// Arrange
+		service, mock := newService(t, 2)
+
+		pingErr := errors.New("connection refused")
+
+		mock.ExpectPing().WillReturnError(pingErr)
+		mock.ExpectPing().WillReturnError(pingErr)
+
+		// Act
+		err := service.WaitUntilReady(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, pingErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should stop retrying when the context is done", func(t *testing.T) {
+		// Arrange
+		service, mock := newService(t, 3)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+		// Act
+		err := service.WaitUntilReady(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Run("Should close the connections", func(t *testing.T) {
+		// Arrange
+		service, mock := newService(t, 1)
+
+		mock.ExpectClose()
+
+		// Act
+		err := service.Close()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether the error was raised by a unique
+// constraint of the database.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == uniqueViolationCode
+	}
+
+	return false
+}
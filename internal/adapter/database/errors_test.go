@@ -0,0 +1,45 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Run("Should return true for a unique violation", func(t *testing.T) {
+		// Arrange
+		err := fmt.Errorf("error inserting: %w", &pq.Error{Code: "23505"})
+
+		// Act
+		res := IsUniqueViolation(err)
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should return false for other database errors", func(t *testing.T) {
+		// Arrange
+		err := &pq.Error{Code: "23503"}
+
+		// Act
+		res := IsUniqueViolation(err)
+
+		// Assert
+		assert.False(t, res)
+	})
+
+	t.Run("Should return false for other errors", func(t *testing.T) {
+		// Arrange
+		err := errors.New("error")
+
+		// Act
+		res := IsUniqueViolation(err)
+
+		// Assert
+		assert.False(t, res)
+	})
+}
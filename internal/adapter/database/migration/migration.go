@@ -0,0 +1,372 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+const (
+	tableName = "schema_migrations"
+
+	// lockKey identifies the advisory lock held while migrating, any pod
+	// running the migrations at the same time waits for it to be released
+	lockKey int64 = 7_352_981_046
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var (
+	ErrInvalidMigration  = errors.New("invalid migration")
+	ErrUnknownMigrations = errors.New("the database has migrations unknown to this version")
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+func (s MigrationStatus) IsApplied() bool {
+	return s.AppliedAt != nil
+}
+
+type Migrator interface {
+	// Up applies every pending migration, each one in its own transaction,
+	// and returns the applied ones.
+	Up(ctx context.Context) ([]Migration, error)
+	// Down reverts the latest applied migration, if any.
+	Down(ctx context.Context) (*Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+// Load returns the migrations embedded in the binary.
+func Load() ([]Migration, error) {
+	return Parse(files, "sql")
+}
+
+// Parse reads the migrations of the directory, named as
+// <version>_<name>.up.sql and <version>_<name>.down.sql, ordered by version.
+func Parse(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("%w: unexpected file name %s", ErrInvalidMigration, entry.Name())
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%w: invalid version in %s", ErrInvalidMigration, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrInvalidMigration, version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: version %d must have both the up and the down files", ErrInvalidMigration, migration.Version)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type migrator struct {
+	conn       *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(conn *sql.DB, migrations []Migration) Migrator {
+	return &migrator{
+		conn:       conn,
+		migrations: migrations,
+	}
+}
+
+func (m *migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := make([]Migration, 0)
+
+	for {
+		migration, err := m.upNext(ctx)
+		if err != nil {
+			return applied, err
+		}
+
+		if migration == nil {
+			return applied, nil
+		}
+
+		applied = append(applied, *migration)
+	}
+}
+
+// upNext applies the first pending migration. The pending one is only looked
+// up after taking the lock, so a pod that waited for another one to finish
+// doesn't apply the same migration again. The migrations unknown to this
+// version are ignored, they were applied by a newer version still running
+// alongside this one, as during a rollout or a rollback.
+func (m *migrator) upNext(ctx context.Context) (*Migration, error) {
+	var next *Migration
+
+	err := m.withLock(ctx, func(tx *sql.Tx) error {
+		versions, err := appliedVersions(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for i := range m.migrations {
+			if _, ok := versions[m.migrations[i].Version]; !ok {
+				next = &m.migrations[i]
+				break
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, next.Up); err != nil {
+			return fmt.Errorf("error applying migration %d_%s: %w", next.Version, next.Name, err)
+		}
+
+		sql, params, err := goqu.
+			Insert(tableName).
+			Rows(goqu.Record{
+				"version": next.Version,
+				"name":    next.Name,
+			}).
+			ToSQL()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, sql, params...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func (m *migrator) Down(ctx context.Context) (*Migration, error) {
+	var latest *Migration
+
+	err := m.withLock(ctx, func(tx *sql.Tx) error {
+		versions, err := appliedVersions(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := m.checkKnown(versions); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := versions[m.migrations[i].Version]; ok {
+				latest = &m.migrations[i]
+				break
+			}
+		}
+
+		if latest == nil {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, latest.Down); err != nil {
+			return fmt.Errorf("error reverting migration %d_%s: %w", latest.Version, latest.Name, err)
+		}
+
+		sql, params, err := goqu.
+			Delete(tableName).
+			Where(goqu.Ex{
+				"version": latest.Version,
+			}).
+			ToSQL()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, sql, params...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := m.withLock(ctx, func(tx *sql.Tx) error {
+		versions, err := appliedVersions(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{
+				Version: migration.Version,
+				Name:    migration.Name,
+			}
+
+			if appliedAt, ok := versions[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// withLock runs fn in a transaction holding the advisory lock of the
+// migrations, the lock is released by postgres when the transaction ends.
+func (m *migrator) withLock(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", lockKey); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version int NOT NULL,
+    name varchar(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (version)
+)`); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkKnown refuses to revert the migrations of a database changed by a
+// newer version of the service, the latest one would be left applied.
+func (m *migrator) checkKnown(versions map[int]time.Time) error {
+	known := make(map[int]struct{}, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = struct{}{}
+	}
+
+	for version := range versions {
+		if _, ok := known[version]; !ok {
+			return fmt.Errorf("%w: version %d", ErrUnknownMigrations, version)
+		}
+	}
+
+	return nil
+}
+
+func appliedVersions(ctx context.Context, tx *sql.Tx) (map[int]time.Time, error) {
+	sql, params, err := goqu.
+		From(tableName).
+		Select("version", "applied_at").
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		versions[version] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,362 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+var migrations = []migration.Migration{
+	{Version: 1, Name: "create_customers", Up: "CREATE TABLE customers", Down: "DROP TABLE customers"},
+	{Version: 2, Name: "create_outbox", Up: "CREATE TABLE outbox", Down: "DROP TABLE outbox"},
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock(.+)").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations(.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(versions ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+
+	for _, version := range versions {
+		rows.AddRow(version, time.Now())
+	}
+
+	return rows
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Should load the embedded migrations in order", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := migration.Load()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res)
+
+		for i, migration := range res {
+			assert.Equal(t, i+1, migration.Version)
+			assert.NotEmpty(t, migration.Up)
+			assert.NotEmpty(t, migration.Down)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Should parse the migrations ordered by version", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"sql/0002_create_outbox.up.sql":      {Data: []byte("CREATE TABLE outbox")},
+			"sql/0002_create_outbox.down.sql":    {Data: []byte("DROP TABLE outbox")},
+			"sql/0001_create_customers.up.sql":   {Data: []byte("CREATE TABLE customers")},
+			"sql/0001_create_customers.down.sql": {Data: []byte("DROP TABLE customers")},
+		}
+
+		// Act
+		res, err := migration.Parse(fsys, "sql")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, migrations, res)
+	})
+
+	t.Run("Should return an error when a file name is not valid", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"sql/create_customers.sql": {Data: []byte("CREATE TABLE customers")},
+		}
+
+		// Act
+		_, err := migration.Parse(fsys, "sql")
+
+		// Assert
+		assert.ErrorIs(t, err, migration.ErrInvalidMigration)
+	})
+
+	t.Run("Should return an error when the down file is missing", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"sql/0001_create_customers.up.sql": {Data: []byte("CREATE TABLE customers")},
+		}
+
+		// Act
+		_, err := migration.Parse(fsys, "sql")
+
+		// Assert
+		assert.ErrorIs(t, err, migration.ErrInvalidMigration)
+	})
+
+	t.Run("Should return an error when a version is used twice", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"sql/0001_create_customers.up.sql":   {Data: []byte("CREATE TABLE customers")},
+			"sql/0001_create_customers.down.sql": {Data: []byte("DROP TABLE customers")},
+			"sql/0001_create_outbox.up.sql":      {Data: []byte("CREATE TABLE outbox")},
+			"sql/0001_create_outbox.down.sql":    {Data: []byte("DROP TABLE outbox")},
+		}
+
+		// Act
+		_, err := migration.Parse(fsys, "sql")
+
+		// Assert
+		assert.ErrorIs(t, err, migration.ErrInvalidMigration)
+	})
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("Should apply the pending migrations", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1))
+		mock.ExpectExec("CREATE TABLE outbox").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO (.+)?schema_migrations(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1, 2))
+		mock.ExpectCommit()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []migration.Migration{migrations[1]}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when a migration fails", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows())
+		mock.ExpectExec("CREATE TABLE customers").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should ignore the migrations applied by a newer version", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1, 2, 3))
+		mock.ExpectCommit()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Up(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the lock cannot be taken", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock(.+)").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		_, err = migrator.Up(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("Should revert the latest applied migration", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1, 2))
+		mock.ExpectExec("DROP TABLE outbox").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM (.+)?schema_migrations(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Down(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &migrations[1], res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should do nothing when no migration was applied", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows())
+		mock.ExpectCommit()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Down(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the database has unknown migrations", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1, 2, 3))
+		mock.ExpectRollback()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Down(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, migration.ErrUnknownMigrations)
+		assert.Nil(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the migration cannot be reverted", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1))
+		mock.ExpectExec("DROP TABLE customers").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Down(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	t.Run("Should return the status of each migration", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnRows(appliedRows(1))
+		mock.ExpectCommit()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Status(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, 1, res[0].Version)
+		assert.True(t, res[0].IsApplied())
+		assert.Equal(t, 2, res[1].Version)
+		assert.False(t, res[1].IsApplied())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?schema_migrations(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		migrator := migration.NewMigrator(db, migrations)
+
+		// Act
+		res, err := migrator.Status(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id varchar(255),
+    document_id varchar(255),
+    document_type int,
+    is_anonymous boolean,
+    version integer NOT NULL DEFAULT 1,
+    password varchar(255),
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+-- databases created by the former init-db.sql already have the table, without
+-- the columns added since
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+-- the former schema allowed the same document to be registered more than
+-- once, the customers are referenced by other services so the duplicated ones
+-- must be reconciled by hand before the unique index is created
+DO $$
+DECLARE
+    duplicated text;
+BEGIN
+    SELECT string_agg(document_id, ', ' ORDER BY document_id) INTO duplicated
+    FROM (
+        SELECT document_id
+        FROM customers
+        WHERE document_id <> ''
+        GROUP BY document_id
+        HAVING COUNT(*) > 1
+    ) AS documents;
+
+    IF duplicated IS NOT NULL THEN
+        RAISE EXCEPTION 'customers has duplicated documents: %', duplicated;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customers_document_id ON customers (document_id) WHERE document_id <> '';
@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS customer_deletion_requests (
+    id varchar(255),
+    customer_id varchar(255),
+    name varchar(255),
+    address varchar(255),
+    phone varchar(255),
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    attempts int NOT NULL DEFAULT 0,
+    last_error text NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    executed_at TIMESTAMP NULL,
+    cancelled_at TIMESTAMP NULL,
+    rejected_at TIMESTAMP NULL,
+    rejection_reason text NOT NULL DEFAULT '',
+    scheduled_for TIMESTAMP NOT NULL,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+-- databases created by the former init-db.sql already have the table, with an
+-- executed flag instead of the status and without the execution columns
+ALTER TABLE customer_deletion_requests
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS attempts int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_error text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS executed_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS rejected_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS rejection_reason text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMP NULL;
+
+-- the requests not yet executed are scheduled after the default grace period
+-- of 30 days from their creation, as the new ones are
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema()
+            AND table_name = 'customer_deletion_requests'
+            AND column_name = 'executed'
+    ) THEN
+        UPDATE customer_deletion_requests
+        SET status = CASE WHEN executed THEN 'executed' ELSE 'pending' END,
+            executed_at = CASE WHEN executed THEN COALESCE(updated_at, NOW()) END;
+
+        ALTER TABLE customer_deletion_requests DROP COLUMN executed;
+    END IF;
+END $$;
+
+UPDATE customer_deletion_requests
+SET scheduled_for = COALESCE(scheduled_for, COALESCE(created_at, NOW()) + INTERVAL '30 days'),
+    next_attempt_at = COALESCE(next_attempt_at, COALESCE(created_at, NOW()) + INTERVAL '30 days'),
+    locked_until = COALESCE(locked_until, COALESCE(created_at, NOW()))
+WHERE scheduled_for IS NULL OR next_attempt_at IS NULL OR locked_until IS NULL;
+
+ALTER TABLE customer_deletion_requests
+    ALTER COLUMN next_attempt_at SET NOT NULL,
+    ALTER COLUMN locked_until SET NOT NULL,
+    ALTER COLUMN scheduled_for SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_customer_deletion_requests_status ON customer_deletion_requests (status, created_at);
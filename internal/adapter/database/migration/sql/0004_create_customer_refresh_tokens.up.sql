@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS customer_refresh_tokens (
+    id varchar(255),
+    customer_id varchar(255) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_refresh_tokens_token_hash ON customer_refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_customer_refresh_tokens_customer_id ON customer_refresh_tokens (customer_id);
@@ -0,0 +1,5 @@
+ALTER TABLE customer_refresh_tokens DROP CONSTRAINT IF EXISTS fk_customer_refresh_tokens_customer_id;
+
+DROP INDEX IF EXISTS idx_customer_deletion_requests_customer_id;
+
+ALTER TABLE customer_deletion_requests DROP CONSTRAINT IF EXISTS fk_customer_deletion_requests_customer_id;
@@ -0,0 +1,11 @@
+-- the deletion requests outlive the customers removed by the delete strategy
+-- and keep their id, to notify which customer was removed, so they have no
+-- foreign key to the customers
+CREATE INDEX IF NOT EXISTS idx_customer_deletion_requests_customer_id ON customer_deletion_requests (customer_id, created_at);
+
+DELETE FROM customer_refresh_tokens
+WHERE customer_id NOT IN (SELECT id FROM customers);
+
+ALTER TABLE customer_refresh_tokens
+    ADD CONSTRAINT fk_customer_refresh_tokens_customer_id
+    FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE;
@@ -0,0 +1,17 @@
+-- the encrypted rows cannot be read without the data keys, so they must be
+-- deleted or decrypted before the columns are dropped
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM customer_deletion_requests WHERE key_version <> 0) THEN
+        RAISE EXCEPTION 'customer_deletion_requests has encrypted rows';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_customer_deletion_requests_key_version;
+
+ALTER TABLE customer_deletion_requests
+    DROP COLUMN IF EXISTS key_version,
+    DROP COLUMN IF EXISTS data_key,
+    ALTER COLUMN name TYPE varchar(255),
+    ALTER COLUMN address TYPE varchar(255),
+    ALTER COLUMN phone TYPE varchar(255);
@@ -0,0 +1,11 @@
+-- the contact data is stored encrypted along with the wrapped data key, both
+-- base64 encoded, the existing rows are kept in plaintext with the key
+-- version 0 until the re-encryption job moves them to the current version
+ALTER TABLE customer_deletion_requests
+    ALTER COLUMN name TYPE text,
+    ALTER COLUMN address TYPE text,
+    ALTER COLUMN phone TYPE text,
+    ADD COLUMN IF NOT EXISTS data_key text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS key_version int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_customer_deletion_requests_key_version ON customer_deletion_requests (key_version);
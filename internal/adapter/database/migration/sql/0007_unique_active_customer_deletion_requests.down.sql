@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_customer_deletion_requests_active_customer_id;
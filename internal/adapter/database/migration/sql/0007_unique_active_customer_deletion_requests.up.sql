@@ -0,0 +1,14 @@
+-- the duplicated active requests created before the index keep only the
+-- oldest one, the others are cancelled as the customer would have done
+WITH duplicated AS (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY customer_id ORDER BY created_at, id) AS position
+    FROM customer_deletion_requests
+    WHERE customer_id IS NOT NULL AND status IN ('pending', 'executing')
+)
+UPDATE customer_deletion_requests
+SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+WHERE id IN (SELECT id FROM duplicated WHERE position > 1);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_deletion_requests_active_customer_id
+    ON customer_deletion_requests (customer_id)
+    WHERE status IN ('pending', 'executing');
@@ -0,0 +1,2 @@
+-- nothing to restore, 0005 no longer adds the foreign key
+SELECT 1;
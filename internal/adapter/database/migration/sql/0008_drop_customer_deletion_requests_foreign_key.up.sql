@@ -0,0 +1,3 @@
+-- databases migrated before 0005 stopped adding it, the deletion requests must
+-- keep the id of the customers removed by the delete strategy
+ALTER TABLE customer_deletion_requests DROP CONSTRAINT IF EXISTS fk_customer_deletion_requests_customer_id;
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+type RowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ExecContext runs the statement within a span named after its operation and
+// table. The statement itself is left out of the span as goqu interpolates
+// the values, which would leak the personal data of the customers.
+func ExecContext(ctx context.Context, conn Execer, table string, query string, args ...any) (sql.Result, error) {
+	ctx, span := startSpan(ctx, table, query)
+
+	result, err := conn.ExecContext(ctx, query, args...)
+
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func QueryContext(ctx context.Context, conn Querier, table string, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startSpan(ctx, table, query)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+func QueryRowContext(ctx context.Context, conn RowQuerier, table string, query string, args ...any) *sql.Row {
+	ctx, span := startSpan(ctx, table, query)
+
+	row := conn.QueryRowContext(ctx, query, args...)
+
+	tracing.End(span, row.Err())
+
+	return row
+}
+
+func startSpan(ctx context.Context, table string, query string) (context.Context, trace.Span) {
+	operation := "QUERY"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	return tracing.Start(ctx, operation+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBSQLTable(table),
+		))
+}
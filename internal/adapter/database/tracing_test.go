@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestExecContext(t *testing.T) {
+	t.Run("Should run the statement within a span", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE \"customers\"(.+)").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		_, err = ExecContext(context.Background(), db, "customers", `UPDATE "customers" SET "password"='secret'`)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "UPDATE customers", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("db.sql.table", "customers"))
+
+		for _, attr := range spans[0].Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "secret")
+		}
+	})
+
+	t.Run("Should flag the span as failed when the statement fails", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("DELETE FROM \"customers\"(.+)").
+			WillReturnError(errors.New("error"))
+
+		// Act
+		_, err = ExecContext(context.Background(), db, "customers", `DELETE FROM "customers" WHERE "id"='1'`)
+
+		// Assert
+		assert.Error(t, err)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "DELETE customers", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
+func TestQueryContext(t *testing.T) {
+	t.Run("Should run the query within a span", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM \"customers\"").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+		// Act
+		rows, err := QueryContext(context.Background(), db, "customers", `SELECT "id" FROM "customers"`)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, rows.Close())
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "SELECT customers", spans[0].Name())
+	})
+}
+
+func TestQueryRowContext(t *testing.T) {
+	t.Run("Should run the query within a span", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM \"customers\"").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		var total int
+
+		// Act
+		err = QueryRowContext(context.Background(), db, "customers", `SELECT COUNT(*) FROM "customers"`).Scan(&total)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "SELECT customers", spans[0].Name())
+	})
+}
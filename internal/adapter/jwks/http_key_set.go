@@ -0,0 +1,250 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+)
+
+const (
+	// minRefreshInterval avoids fetching the key set for every token signed
+	// with an unknown key id, which anyone is able to forge
+	minRefreshInterval = 10 * time.Second
+)
+
+type HttpKeySet struct {
+	url             string
+	refreshInterval time.Duration
+
+	client       *http.Client
+	timeProvider provider.TimeProvider
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewKeySet(url string, refreshInterval time.Duration, client *http.Client, timeProvider provider.TimeProvider) KeySet {
+	return &HttpKeySet{
+		url:             url,
+		refreshInterval: refreshInterval,
+		client:          client,
+		timeProvider:    timeProvider,
+		keys:            make(map[string]interface{}),
+	}
+}
+
+// GetKey returns the public key with the given id. The key set is fetched
+// again when it is older than the refresh interval, or when the key is not
+// known yet, so rotated keys are picked up without a restart.
+func (s *HttpKeySet) GetKey(ctx context.Context, kid string) (interface{}, error) {
+	now := s.timeProvider.GetTime()
+
+	s.mu.RLock()
+	key, found := s.keys[kid]
+	fetchedAt := s.fetchedAt
+	attemptedAt := s.attemptedAt
+	s.mu.RUnlock()
+
+	expired := now.Sub(fetchedAt) >= s.refreshInterval
+	if found && !expired {
+		return key, nil
+	}
+
+	// the failed fetches count as well, so the provider is not called for
+	// every token while it is not reachable
+	if now.Sub(attemptedAt) < minRefreshInterval {
+		if found {
+			return key, nil
+		}
+
+		return nil, ErrKeyNotFound
+	}
+
+	if err := s.refresh(ctx, now); err != nil {
+		// keep using the cached key while the provider is not reachable
+		if found {
+			slog.WarnContext(ctx, "error refreshing the key set, using the cached key", "url", s.url, "error", err)
+			return key, nil
+		}
+
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, found = s.keys[kid]
+	if !found {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (s *HttpKeySet) refresh(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// another request may have refreshed the keys while this one was waiting
+	if now.Sub(s.attemptedAt) < minRefreshInterval {
+		return nil
+	}
+
+	// the cached keys are kept when the fetch fails
+	s.attemptedAt = now
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+	s.fetchedAt = now
+
+	return nil
+}
+
+func (s *HttpKeySet) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching the key set", res.StatusCode)
+	}
+
+	var keySet jsonWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&keySet); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(keySet.Keys))
+
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			slog.WarnContext(ctx, "ignoring invalid key", "kid", jwk.Kid, "error", err)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		return k.rsaPublicKey()
+	case "EC":
+		return k.ecdsaPublicKey()
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.IsInt64() {
+		return nil, fmt.Errorf("invalid rsa exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: n,
+		E: int(e.Int64()),
+	}, nil
+}
+
+func (k jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("point is not on the curve %q", k.Crv)
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(bytes), nil
+}
@@ -0,0 +1,231 @@
+package jwks_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/jwks"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaKey(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"use": "sig",
+		"n":   encode(key.N),
+		"e":   encode(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecKey(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   encode(key.X),
+		"y":   encode(key.Y),
+	}
+}
+
+type keySetServer struct {
+	keys     atomic.Value
+	requests atomic.Int32
+	status   atomic.Int32
+}
+
+func newKeySetServer(t *testing.T, keys ...map[string]string) (*keySetServer, *httptest.Server) {
+	state := &keySetServer{}
+	state.keys.Store(keys)
+	state.status.Store(http.StatusOK)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state.requests.Add(1)
+
+		w.WriteHeader(int(state.status.Load()))
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": state.keys.Load(),
+		})
+		assert.NoError(t, err)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return state, server
+}
+
+func TestGetKey(t *testing.T) {
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ecPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	t.Run("Should return the keys of the key set", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t,
+			rsaKey("rsa-key", &rsaPrivateKey.PublicKey),
+			ecKey("ec-key", &ecPrivateKey.PublicKey),
+			map[string]string{"kid": "enc-key", "kty": "RSA", "use": "enc"},
+			map[string]string{"kid": "oct-key", "kty": "oct"})
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		// Act
+		rsaRes, rsaErr := keySet.GetKey(ctx, "rsa-key")
+		ecRes, ecErr := keySet.GetKey(ctx, "ec-key")
+
+		// Assert
+		assert.NoError(t, rsaErr)
+		assert.True(t, rsaPrivateKey.PublicKey.Equal(rsaRes))
+		assert.NoError(t, ecErr)
+		assert.True(t, ecPrivateKey.PublicKey.Equal(ecRes))
+		assert.Equal(t, int32(1), state.requests.Load())
+	})
+
+	t.Run("Should fetch the key set again when a key was rotated", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t, rsaKey("old-key", &rsaPrivateKey.PublicKey))
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Once()
+		timeProvider.On("GetTime").Return(now.Add(time.Minute))
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		_, err := keySet.GetKey(ctx, "old-key")
+		assert.NoError(t, err)
+
+		state.keys.Store([]map[string]string{rsaKey("new-key", &rsaPrivateKey.PublicKey)})
+
+		// Act
+		res, err := keySet.GetKey(ctx, "new-key")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, int32(2), state.requests.Load())
+	})
+
+	t.Run("Should not fetch the key set for every unknown key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t, rsaKey("rsa-key", &rsaPrivateKey.PublicKey))
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		// Act
+		_, firstErr := keySet.GetKey(ctx, "unknown-key")
+		_, secondErr := keySet.GetKey(ctx, "unknown-key")
+
+		// Assert
+		assert.ErrorIs(t, firstErr, jwks.ErrKeyNotFound)
+		assert.ErrorIs(t, secondErr, jwks.ErrKeyNotFound)
+		assert.Equal(t, int32(1), state.requests.Load())
+	})
+
+	t.Run("Should keep using the cached key when the key set could not be refreshed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t, rsaKey("rsa-key", &rsaPrivateKey.PublicKey))
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Once()
+		timeProvider.On("GetTime").Return(now.Add(2 * time.Hour))
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		_, err := keySet.GetKey(ctx, "rsa-key")
+		assert.NoError(t, err)
+
+		state.status.Store(http.StatusInternalServerError)
+
+		// Act
+		res, err := keySet.GetKey(ctx, "rsa-key")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, int32(2), state.requests.Load())
+	})
+
+	t.Run("Should return an error when the key set could not be fetched", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t)
+		state.status.Store(http.StatusInternalServerError)
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		// Act
+		res, err := keySet.GetKey(ctx, "rsa-key")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should not fetch the key set for every unknown key while it could not be fetched", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		state, server := newKeySetServer(t)
+		state.status.Store(http.StatusInternalServerError)
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Twice()
+		timeProvider.On("GetTime").Return(now.Add(time.Minute))
+
+		keySet := jwks.NewKeySet(server.URL, time.Hour, server.Client(), timeProvider)
+
+		// Act
+		_, firstErr := keySet.GetKey(ctx, "unknown-key")
+		_, secondErr := keySet.GetKey(ctx, "unknown-key")
+		_, thirdErr := keySet.GetKey(ctx, "unknown-key")
+
+		// Assert
+		assert.Error(t, firstErr)
+		assert.NotErrorIs(t, firstErr, jwks.ErrKeyNotFound)
+		assert.ErrorIs(t, secondErr, jwks.ErrKeyNotFound)
+		assert.Error(t, thirdErr)
+		assert.Equal(t, int32(2), state.requests.Load())
+	})
+}
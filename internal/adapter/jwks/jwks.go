@@ -0,0 +1,14 @@
+package jwks
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrKeyNotFound = errors.New("key not found in the key set")
+)
+
+type KeySet interface {
+	GetKey(ctx context.Context, kid string) (interface{}, error)
+}
@@ -1,12 +1,58 @@
 package entity
 
-import "time"
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/mask"
+)
 
 type Customer struct {
-	Id          string    `json:"id"`
-	DocumentId  string    `json:"document_id"`
-	Password    string    `json:"password"`
-	IsAnonymous bool      `json:"is_anonymous"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Id           string        `json:"id"`
+	DocumentId   string        `json:"document_id"`
+	DocumentType document.Type `json:"document_type"`
+	Password     string        `json:"-"`
+	IsAnonymous  bool          `json:"is_anonymous"`
+	Version      int           `json:"-"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// NewCustomer expects the document already normalized, see document.Parse.
+func NewCustomer(documentId string, documentType document.Type, password string, now time.Time) Customer {
+	return Customer{
+		Id:           uuid.NewString(),
+		DocumentId:   documentId,
+		DocumentType: documentType,
+		Password:     password,
+		IsAnonymous:  false,
+		Version:      1,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// NewAnonymousCustomer creates a customer that is not identified by any
+// document, so it has no credentials.
+func NewAnonymousCustomer(now time.Time) Customer {
+	return Customer{
+		Id:          uuid.NewString(),
+		IsAnonymous: true,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// LogValue keeps the document and the password of the customer out of the logs.
+func (c Customer) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", c.Id),
+		slog.String("document_id", mask.Document(c.DocumentId)),
+		slog.String("document_type", c.DocumentType.String()),
+		slog.Bool("is_anonymous", c.IsAnonymous),
+		slog.Int("version", c.Version),
+	)
 }
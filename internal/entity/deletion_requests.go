@@ -1,23 +1,58 @@
 package entity
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/mask"
 )
 
+type DeletionRequestStatus string
+
+const (
+	DeletionRequestStatusPending   DeletionRequestStatus = "pending"
+	DeletionRequestStatusCancelled DeletionRequestStatus = "cancelled"
+	DeletionRequestStatusExecuting DeletionRequestStatus = "executing"
+	DeletionRequestStatusExecuted  DeletionRequestStatus = "executed"
+	DeletionRequestStatusFailed    DeletionRequestStatus = "failed"
+	DeletionRequestStatusRejected  DeletionRequestStatus = "rejected"
+)
+
+var DeletionRequestStatuses = []DeletionRequestStatus{
+	DeletionRequestStatusPending,
+	DeletionRequestStatusCancelled,
+	DeletionRequestStatusExecuting,
+	DeletionRequestStatusExecuted,
+	DeletionRequestStatusFailed,
+	DeletionRequestStatusRejected,
+}
+
 type DeletionRequest struct {
-	Id         string    `json:"id"`
-	CustomerId string    `json:"customer_id"`
-	Name       string    `json:"name"`
-	Address    string    `json:"address"`
-	Phone      string    `json:"phone"`
-	Executed   bool      `json:"executed"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	Id              string                `json:"id"`
+	CustomerId      string                `json:"customer_id"`
+	Name            string                `json:"name"`
+	Address         string                `json:"address"`
+	Phone           string                `json:"phone"`
+	Status          DeletionRequestStatus `json:"status"`
+	Attempts        int                   `json:"attempts"`
+	LastError       string                `json:"last_error"`
+	NextAttemptAt   time.Time             `json:"next_attempt_at"`
+	LockedUntil     time.Time             `json:"locked_until"`
+	ExecutedAt      *time.Time            `json:"executed_at,omitempty"`
+	CancelledAt     *time.Time            `json:"cancelled_at,omitempty"`
+	RejectedAt      *time.Time            `json:"rejected_at,omitempty"`
+	RejectionReason string                `json:"rejection_reason"`
+	ScheduledFor    time.Time             `json:"scheduled_for"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
 }
 
-func NewDeleteRequest(customerId, name, address, phone string) DeletionRequest {
+// NewDeleteRequest creates a pending request that will only be executed once
+// the grace period is over, giving the customer the chance to cancel it.
+func NewDeleteRequest(customerId, name, address, phone string, now time.Time, gracePeriod time.Duration) DeletionRequest {
+	scheduledFor := now.Add(gracePeriod)
+
 	return DeletionRequest{
 		Id: uuid.NewString(),
 
@@ -26,8 +61,92 @@ func NewDeleteRequest(customerId, name, address, phone string) DeletionRequest {
 		Address:    address,
 		Phone:      phone,
 
-		Executed:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Status:        DeletionRequestStatusPending,
+		NextAttemptAt: scheduledFor,
+		ScheduledFor:  scheduledFor,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 }
+
+// IsActive reports whether the request is still going to be, or is being,
+// executed. Only one active request is allowed per customer.
+func (r *DeletionRequest) IsActive() bool {
+	return r.Status == DeletionRequestStatusPending || r.Status == DeletionRequestStatusExecuting
+}
+
+func (r *DeletionRequest) IsExecuted() bool {
+	return r.Status == DeletionRequestStatusExecuted
+}
+
+func (r *DeletionRequest) CanBeCancelled() bool {
+	return r.Status == DeletionRequestStatusPending
+}
+
+func (r *DeletionRequest) Cancel(now time.Time) {
+	r.Status = DeletionRequestStatusCancelled
+	r.CancelledAt = &now
+	r.UpdatedAt = now
+}
+
+// CanBeExecuted reports whether the request can be executed on demand,
+// skipping the grace period or the retries backoff. An executing request is
+// only executed again when its lock has expired.
+func (r *DeletionRequest) CanBeExecuted(now time.Time) bool {
+	switch r.Status {
+	case DeletionRequestStatusPending, DeletionRequestStatusFailed:
+		return true
+	case DeletionRequestStatusExecuting:
+		return !r.LockedUntil.After(now)
+	default:
+		return false
+	}
+}
+
+func (r *DeletionRequest) CanBeRejected() bool {
+	return r.Status == DeletionRequestStatusPending
+}
+
+func (r *DeletionRequest) Reject(reason string, now time.Time) {
+	r.Status = DeletionRequestStatusRejected
+	r.RejectionReason = reason
+	r.RejectedAt = &now
+	r.UpdatedAt = now
+}
+
+func (r *DeletionRequest) MarkAsExecuted(now time.Time) {
+	r.Status = DeletionRequestStatusExecuted
+	r.LastError = ""
+	r.LockedUntil = now
+	r.ExecutedAt = &now
+	r.UpdatedAt = now
+}
+
+// MarkAsFailed records a failed attempt. The request goes back to pending to
+// be retried at nextAttemptAt, unless maxAttempts was reached.
+func (r *DeletionRequest) MarkAsFailed(err error, now time.Time, nextAttemptAt time.Time, maxAttempts int) {
+	r.Attempts++
+	r.LastError = err.Error()
+	r.NextAttemptAt = nextAttemptAt
+	r.LockedUntil = now
+	r.UpdatedAt = now
+
+	r.Status = DeletionRequestStatusPending
+	if r.Attempts >= maxAttempts {
+		r.Status = DeletionRequestStatusFailed
+	}
+}
+
+// LogValue keeps the contact data of the customer out of the logs.
+func (r DeletionRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", r.Id),
+		slog.String("customer_id", r.CustomerId),
+		slog.String("name", mask.Name(r.Name)),
+		slog.String("address", mask.Address(r.Address)),
+		slog.String("phone", mask.Phone(r.Phone)),
+		slog.String("status", string(r.Status)),
+		slog.Int("attempts", r.Attempts),
+		slog.Time("scheduled_for", r.ScheduledFor),
+	)
+}
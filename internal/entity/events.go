@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeCustomerDeletionRequested EventType = "CustomerDeletionRequested"
+	EventTypeCustomerDeleted           EventType = "CustomerDeleted"
+)
+
+const (
+	// EventVersion must be bumped on breaking changes of the events data,
+	// the subscribers rely on it to know how to read the events
+	EventVersion = 1
+)
+
+type Event struct {
+	Id            string      `json:"id"`
+	Type          EventType   `json:"type"`
+	Version       int         `json:"version"`
+	CorrelationId string      `json:"correlation_id"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+}
+
+type CustomerDeletionRequestedData struct {
+	CustomerId        string    `json:"customer_id"`
+	DeletionRequestId string    `json:"deletion_request_id"`
+	ScheduledFor      time.Time `json:"scheduled_for"`
+}
+
+type CustomerDeletedData struct {
+	CustomerId        string    `json:"customer_id"`
+	DeletionRequestId string    `json:"deletion_request_id"`
+	Strategy          string    `json:"strategy"`
+	DeletedAt         time.Time `json:"deleted_at"`
+}
+
+func newEvent(eventType EventType, correlationId string, now time.Time, data interface{}) Event {
+	return Event{
+		Id:            uuid.NewString(),
+		Type:          eventType,
+		Version:       EventVersion,
+		CorrelationId: correlationId,
+		OccurredAt:    now,
+		Data:          data,
+	}
+}
+
+// NewCustomerDeletionRequestedEvent uses the deletion request id as the
+// correlation id, so it can be matched with the CustomerDeleted event.
+func NewCustomerDeletionRequestedEvent(request DeletionRequest, now time.Time) Event {
+	return newEvent(EventTypeCustomerDeletionRequested, request.Id, now, CustomerDeletionRequestedData{
+		CustomerId:        request.CustomerId,
+		DeletionRequestId: request.Id,
+		ScheduledFor:      request.ScheduledFor,
+	})
+}
+
+func NewCustomerDeletedEvent(request DeletionRequest, strategy string, now time.Time) Event {
+	return newEvent(EventTypeCustomerDeleted, request.Id, now, CustomerDeletedData{
+		CustomerId:        request.CustomerId,
+		DeletionRequestId: request.Id,
+		Strategy:          strategy,
+		DeletedAt:         now,
+	})
+}
+
+func (e Event) GetMessageAttributes() map[string]string {
+	return map[string]string{
+		"event_type":     string(e.Type),
+		"event_version":  strconv.Itoa(e.Version),
+		"correlation_id": e.CorrelationId,
+	}
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxMessageStatus string
+
+const (
+	OutboxMessageStatusPending OutboxMessageStatus = "pending"
+	OutboxMessageStatusSent    OutboxMessageStatus = "sent"
+	OutboxMessageStatusFailed  OutboxMessageStatus = "failed"
+)
+
+type OutboxMessage struct {
+	Id            string              `json:"id"`
+	EventType     EventType           `json:"event_type"`
+	EventVersion  int                 `json:"event_version"`
+	CorrelationId string              `json:"correlation_id"`
+	Payload       string              `json:"payload"`
+	Status        OutboxMessageStatus `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	LastError     string              `json:"last_error"`
+	NextAttemptAt time.Time           `json:"next_attempt_at"`
+	LockedUntil   time.Time           `json:"locked_until"`
+	SentAt        *time.Time          `json:"sent_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// NewOutboxMessage stores the event to be published later by the relay, it
+// must be saved in the same transaction of the change that raised the event.
+func NewOutboxMessage(event Event) (OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+
+	return OutboxMessage{
+		Id:            uuid.NewString(),
+		EventType:     event.Type,
+		EventVersion:  event.Version,
+		CorrelationId: event.CorrelationId,
+		Payload:       string(payload),
+		Status:        OutboxMessageStatusPending,
+		NextAttemptAt: event.OccurredAt,
+		LockedUntil:   event.OccurredAt,
+		CreatedAt:     event.OccurredAt,
+		UpdatedAt:     event.OccurredAt,
+	}, nil
+}
+
+func (m *OutboxMessage) MarkAsSent(now time.Time) {
+	m.Status = OutboxMessageStatusSent
+	m.LastError = ""
+	m.LockedUntil = now
+	m.SentAt = &now
+	m.UpdatedAt = now
+}
+
+// MarkAsFailed records a failed attempt. The message goes back to pending to
+// be retried at nextAttemptAt, unless maxAttempts was reached.
+func (m *OutboxMessage) MarkAsFailed(err error, now time.Time, nextAttemptAt time.Time, maxAttempts int) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = nextAttemptAt
+	m.LockedUntil = now
+	m.UpdatedAt = now
+
+	m.Status = OutboxMessageStatusPending
+	if m.Attempts >= maxAttempts {
+		m.Status = OutboxMessageStatusFailed
+	}
+}
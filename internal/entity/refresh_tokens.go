@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is kept server side so it can be revoked, only the hash of
+// the token is stored, the token itself is only known by the customer.
+type RefreshToken struct {
+	Id         string     `json:"id"`
+	CustomerId string     `json:"customer_id"`
+	TokenHash  string     `json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func NewRefreshToken(customerId string, tokenHash string, now time.Time, ttl time.Duration) RefreshToken {
+	return RefreshToken{
+		Id:         uuid.NewString(),
+		CustomerId: customerId,
+		TokenHash:  tokenHash,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func (t RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -2,35 +2,191 @@ package environment
 
 import (
 	"context"
+	"time"
 )
 
 type ApiConfig struct {
-	Port       int    `env:"PORT, default=5000"`
-	EnvName    string `env:"ENV_NAME, default=development"`
-	ApiVersion string `env:"VERSION, default=v1"`
+	Port               int           `env:"PORT, default=5000"`
+	MetricsPort        int           `env:"METRICS_PORT, default=9090"`
+	EnvName            string        `env:"ENV_NAME, default=development"`
+	ApiVersion         string        `env:"VERSION, default=v1"`
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT, default=2s"`
+	LogLevel           string        `env:"LOG_LEVEL"`
+	IdentifyRateLimit  float64       `env:"IDENTIFY_RATE_LIMIT, default=1"`
+	IdentifyRateBurst  int           `env:"IDENTIFY_RATE_BURST, default=5"`
 }
 
 func (c *ApiConfig) IsDevelopment() bool {
 	return c.EnvName == "development"
 }
 
+func (c *ApiConfig) IsLogLevelSet() bool {
+	return c.LogLevel != ""
+}
+
 type DatabaseConfig struct {
-	Url           string `env:"URL, required"`
-	UrlSecretName string `env:"URL_SECRET_NAME, required"`
+	Url              string `env:"URL, required"`
+	UrlSecretName    string `env:"URL_SECRET_NAME, required"`
+	MigrateOnStartup bool   `env:"MIGRATE_ON_STARTUP, default=true"`
+
+	MaxOpenConns    int           `env:"MAX_OPEN_CONNS, default=25"`
+	MaxIdleConns    int           `env:"MAX_IDLE_CONNS, default=10"`
+	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME, default=30m"`
+	ConnMaxIdleTime time.Duration `env:"CONN_MAX_IDLE_TIME, default=5m"`
+
+	ConnectTimeout         time.Duration `env:"CONNECT_TIMEOUT, default=5s"`
+	ConnectMaxAttempts     int           `env:"CONNECT_MAX_ATTEMPTS, default=5"`
+	ConnectRetryBackoff    time.Duration `env:"CONNECT_RETRY_BACKOFF, default=1s"`
+	ConnectMaxRetryBackoff time.Duration `env:"CONNECT_MAX_RETRY_BACKOFF, default=15s"`
 }
 
 type CloudConfig struct {
 	BaseEndpoint string `env:"BASE_ENDPOINT"`
+
+	DeletionQueueName string `env:"DELETION_QUEUE_NAME"`
+	DeletionTopicName string `env:"DELETION_TOPIC_NAME"`
+
+	QueueWaitTimeSeconds          int32 `env:"QUEUE_WAIT_TIME_SECONDS, default=20"`
+	QueueVisibilityTimeoutSeconds int32 `env:"QUEUE_VISIBILITY_TIMEOUT_SECONDS, default=30"`
+	QueueMaxMessages              int32 `env:"QUEUE_MAX_MESSAGES, default=10"`
+	QueueMaxReceiveCount          int32 `env:"QUEUE_MAX_RECEIVE_COUNT, default=5"`
 }
 
 func (c *CloudConfig) IsBaseEndpointSet() bool {
 	return c.BaseEndpoint != ""
 }
 
+func (c *CloudConfig) IsDeletionQueueSet() bool {
+	return c.DeletionQueueName != ""
+}
+
+func (c *CloudConfig) IsDeletionTopicSet() bool {
+	return c.DeletionTopicName != ""
+}
+
+const (
+	DeletionStrategyAnonymize = "anonymize"
+	DeletionStrategyDelete    = "delete"
+)
+
+type DeletionConfig struct {
+	Strategy        string        `env:"STRATEGY, default=anonymize"`
+	GracePeriod     time.Duration `env:"GRACE_PERIOD, default=720h"`
+	PollInterval    time.Duration `env:"POLL_INTERVAL, default=1m"`
+	BatchSize       int           `env:"BATCH_SIZE, default=10"`
+	MaxAttempts     int           `env:"MAX_ATTEMPTS, default=5"`
+	LockDuration    time.Duration `env:"LOCK_DURATION, default=5m"`
+	RetryBackoff    time.Duration `env:"RETRY_BACKOFF, default=1m"`
+	MaxRetryBackoff time.Duration `env:"MAX_RETRY_BACKOFF, default=1h"`
+}
+
+// IsAnonymizationEnabled reports whether customers are anonymized instead of
+// physically deleted. Anything other than an explicit "delete" anonymizes, so
+// a typo never ends up removing rows other services still reference.
+func (c *DeletionConfig) IsAnonymizationEnabled() bool {
+	return c.Strategy != DeletionStrategyDelete
+}
+
+type OutboxConfig struct {
+	PollInterval    time.Duration `env:"POLL_INTERVAL, default=5s"`
+	BatchSize       int           `env:"BATCH_SIZE, default=50"`
+	MaxAttempts     int           `env:"MAX_ATTEMPTS, default=10"`
+	LockDuration    time.Duration `env:"LOCK_DURATION, default=1m"`
+	RetryBackoff    time.Duration `env:"RETRY_BACKOFF, default=10s"`
+	MaxRetryBackoff time.Duration `env:"MAX_RETRY_BACKOFF, default=10m"`
+	CleanupInterval time.Duration `env:"CLEANUP_INTERVAL, default=1h"`
+	Retention       time.Duration `env:"RETENTION, default=168h"`
+}
+
+type AuthConfig struct {
+	Secret              string        `env:"SECRET"`
+	SecretName          string        `env:"SECRET_NAME"`
+	JwksUrl             string        `env:"JWKS_URL"`
+	JwksRefreshInterval time.Duration `env:"JWKS_REFRESH_INTERVAL, default=15m"`
+	Issuer              string        `env:"ISSUER"`
+	Audience            string        `env:"AUDIENCE"`
+	Leeway              time.Duration `env:"LEEWAY, default=30s"`
+	AdminRole           string        `env:"ADMIN_ROLE, default=admin"`
+	KioskRole           string        `env:"KIOSK_ROLE, default=kiosk"`
+	AccessTokenTtl      time.Duration `env:"ACCESS_TOKEN_TTL, default=15m"`
+	RefreshTokenTtl     time.Duration `env:"REFRESH_TOKEN_TTL, default=720h"`
+}
+
+func (c *AuthConfig) IsSecretSet() bool {
+	return c.Secret != ""
+}
+
+func (c *AuthConfig) IsSecretNameSet() bool {
+	return c.SecretName != ""
+}
+
+func (c *AuthConfig) IsJwksSet() bool {
+	return c.JwksUrl != ""
+}
+
+// IsIssuingEnabled reports whether the service can sign its own tokens, it
+// only signs with the shared secret, the JWKS keys are of other issuers.
+func (c *AuthConfig) IsIssuingEnabled() bool {
+	return c.IsSecretSet()
+}
+
+type PasswordConfig struct {
+	HashCost         int  `env:"HASH_COST, default=12"`
+	MigratePlaintext bool `env:"MIGRATE_PLAINTEXT, default=false"`
+	MigrateBatchSize int  `env:"MIGRATE_BATCH_SIZE, default=100"`
+}
+
+const TracingExporterOtlp = "otlp"
+
+type TracingConfig struct {
+	Exporter    string  `env:"EXPORTER, default=none"`
+	Endpoint    string  `env:"ENDPOINT, default=localhost:4318"`
+	Insecure    bool    `env:"INSECURE, default=false"`
+	ServiceName string  `env:"SERVICE_NAME, default=ms-customer-management"`
+	SampleRatio float64 `env:"SAMPLE_RATIO, default=1"`
+}
+
+// IsOtlpEnabled reports whether the spans are exported, any other exporter
+// keeps them in the process, which is enough to propagate the trace context.
+func (c *TracingConfig) IsOtlpEnabled() bool {
+	return c.Exporter == TracingExporterOtlp
+}
+
+const EncryptionProviderKms = "kms"
+
+type EncryptionConfig struct {
+	Provider           string        `env:"PROVIDER, default=local"`
+	Keys               string        `env:"KEYS"`
+	KeysSecretName     string        `env:"KEYS_SECRET_NAME"`
+	CurrentKeyVersion  int           `env:"CURRENT_KEY_VERSION, default=1"`
+	ReencryptInterval  time.Duration `env:"REENCRYPT_INTERVAL, default=1h"`
+	ReencryptBatchSize int           `env:"REENCRYPT_BATCH_SIZE, default=100"`
+}
+
+// IsKmsEnabled reports whether the keys are KMS key ids, any other provider
+// reads them as base64 encoded master keys.
+func (c *EncryptionConfig) IsKmsEnabled() bool {
+	return c.Provider == EncryptionProviderKms
+}
+
+func (c *EncryptionConfig) IsKeysSet() bool {
+	return c.Keys != ""
+}
+
+func (c *EncryptionConfig) IsKeysSecretNameSet() bool {
+	return c.KeysSecretName != ""
+}
+
 type Config struct {
-	ApiConfig   *ApiConfig      `env:",prefix=API_"`
-	DbConfig    *DatabaseConfig `env:",prefix=DB_"`
-	CloudConfig *CloudConfig    `env:",prefix=AWS_"`
+	ApiConfig        *ApiConfig        `env:",prefix=API_"`
+	DbConfig         *DatabaseConfig   `env:",prefix=DB_"`
+	CloudConfig      *CloudConfig      `env:",prefix=AWS_"`
+	AuthConfig       *AuthConfig       `env:",prefix=AUTH_"`
+	PasswordConfig   *PasswordConfig   `env:",prefix=PASSWORD_"`
+	DeletionConfig   *DeletionConfig   `env:",prefix=DELETION_"`
+	OutboxConfig     *OutboxConfig     `env:",prefix=OUTBOX_"`
+	TracingConfig    *TracingConfig    `env:",prefix=TRACING_"`
+	EncryptionConfig *EncryptionConfig `env:",prefix=ENCRYPTION_"`
 }
 
 type Environment interface {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
 	"github.com/stretchr/testify/assert"
@@ -46,16 +47,81 @@ func TestGetEnvironment(t *testing.T) {
 
 		expected := &environment.Config{
 			ApiConfig: &environment.ApiConfig{
-				Port:       5000,
-				EnvName:    "development",
-				ApiVersion: "v1",
+				Port:        5000,
+				MetricsPort: 9090,
+				EnvName:     "development",
+				ApiVersion:  "v1",
+
+				HealthCheckTimeout: 2 * time.Second,
+				IdentifyRateLimit:  1,
+				IdentifyRateBurst:  5,
 			},
 			DbConfig: &environment.DatabaseConfig{
-				Url:           "db://host:1234",
-				UrlSecretName: "db-secret-url",
+				Url:              "db://host:1234",
+				UrlSecretName:    "db-secret-url",
+				MigrateOnStartup: true,
+
+				MaxOpenConns:    25,
+				MaxIdleConns:    10,
+				ConnMaxLifetime: 30 * time.Minute,
+				ConnMaxIdleTime: 5 * time.Minute,
+
+				ConnectTimeout:         5 * time.Second,
+				ConnectMaxAttempts:     5,
+				ConnectRetryBackoff:    time.Second,
+				ConnectMaxRetryBackoff: 15 * time.Second,
 			},
 			CloudConfig: &environment.CloudConfig{
-				BaseEndpoint: "http://localhost:4566",
+				BaseEndpoint:                  "http://localhost:4566",
+				QueueWaitTimeSeconds:          20,
+				QueueVisibilityTimeoutSeconds: 30,
+				QueueMaxMessages:              10,
+				QueueMaxReceiveCount:          5,
+			},
+			AuthConfig: &environment.AuthConfig{
+				JwksRefreshInterval: 15 * time.Minute,
+				Leeway:              30 * time.Second,
+				AdminRole:           "admin",
+				KioskRole:           "kiosk",
+				AccessTokenTtl:      15 * time.Minute,
+				RefreshTokenTtl:     720 * time.Hour,
+			},
+			PasswordConfig: &environment.PasswordConfig{
+				HashCost:         12,
+				MigratePlaintext: false,
+				MigrateBatchSize: 100,
+			},
+			DeletionConfig: &environment.DeletionConfig{
+				Strategy:        "anonymize",
+				GracePeriod:     720 * time.Hour,
+				PollInterval:    time.Minute,
+				BatchSize:       10,
+				MaxAttempts:     5,
+				LockDuration:    5 * time.Minute,
+				RetryBackoff:    time.Minute,
+				MaxRetryBackoff: time.Hour,
+			},
+			OutboxConfig: &environment.OutboxConfig{
+				PollInterval:    5 * time.Second,
+				BatchSize:       50,
+				MaxAttempts:     10,
+				LockDuration:    time.Minute,
+				RetryBackoff:    10 * time.Second,
+				MaxRetryBackoff: 10 * time.Minute,
+				CleanupInterval: time.Hour,
+				Retention:       168 * time.Hour,
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "none",
+				Endpoint:    "localhost:4318",
+				ServiceName: "ms-customer-management",
+				SampleRatio: 1,
+			},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Provider:           "local",
+				CurrentKeyVersion:  1,
+				ReencryptInterval:  time.Hour,
+				ReencryptBatchSize: 100,
 			},
 		}
 
@@ -101,16 +167,81 @@ func TestGetEnvironmentFromFile(t *testing.T) {
 
 		expected := &environment.Config{
 			ApiConfig: &environment.ApiConfig{
-				Port:       5000,
-				EnvName:    "development",
-				ApiVersion: "v1",
+				Port:        5000,
+				MetricsPort: 9090,
+				EnvName:     "development",
+				ApiVersion:  "v1",
+
+				HealthCheckTimeout: 2 * time.Second,
+				IdentifyRateLimit:  1,
+				IdentifyRateBurst:  5,
 			},
 			DbConfig: &environment.DatabaseConfig{
-				Url:           "db://host:1234",
-				UrlSecretName: "db-secret-url",
+				Url:              "db://host:1234",
+				UrlSecretName:    "db-secret-url",
+				MigrateOnStartup: true,
+
+				MaxOpenConns:    25,
+				MaxIdleConns:    10,
+				ConnMaxLifetime: 30 * time.Minute,
+				ConnMaxIdleTime: 5 * time.Minute,
+
+				ConnectTimeout:         5 * time.Second,
+				ConnectMaxAttempts:     5,
+				ConnectRetryBackoff:    time.Second,
+				ConnectMaxRetryBackoff: 15 * time.Second,
 			},
 			CloudConfig: &environment.CloudConfig{
-				BaseEndpoint: "http://localhost:4566",
+				BaseEndpoint:                  "http://localhost:4566",
+				QueueWaitTimeSeconds:          20,
+				QueueVisibilityTimeoutSeconds: 30,
+				QueueMaxMessages:              10,
+				QueueMaxReceiveCount:          5,
+			},
+			AuthConfig: &environment.AuthConfig{
+				JwksRefreshInterval: 15 * time.Minute,
+				Leeway:              30 * time.Second,
+				AdminRole:           "admin",
+				KioskRole:           "kiosk",
+				AccessTokenTtl:      15 * time.Minute,
+				RefreshTokenTtl:     720 * time.Hour,
+			},
+			PasswordConfig: &environment.PasswordConfig{
+				HashCost:         12,
+				MigratePlaintext: false,
+				MigrateBatchSize: 100,
+			},
+			DeletionConfig: &environment.DeletionConfig{
+				Strategy:        "anonymize",
+				GracePeriod:     720 * time.Hour,
+				PollInterval:    time.Minute,
+				BatchSize:       10,
+				MaxAttempts:     5,
+				LockDuration:    5 * time.Minute,
+				RetryBackoff:    time.Minute,
+				MaxRetryBackoff: time.Hour,
+			},
+			OutboxConfig: &environment.OutboxConfig{
+				PollInterval:    5 * time.Second,
+				BatchSize:       50,
+				MaxAttempts:     10,
+				LockDuration:    time.Minute,
+				RetryBackoff:    10 * time.Second,
+				MaxRetryBackoff: 10 * time.Minute,
+				CleanupInterval: time.Hour,
+				Retention:       168 * time.Hour,
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "none",
+				Endpoint:    "localhost:4318",
+				ServiceName: "ms-customer-management",
+				SampleRatio: 1,
+			},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Provider:           "local",
+				CurrentKeyVersion:  1,
+				ReencryptInterval:  time.Hour,
+				ReencryptBatchSize: 100,
 			},
 		}
 
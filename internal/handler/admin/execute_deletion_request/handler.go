@@ -0,0 +1,40 @@
+package execute_deletion_request
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service deletion_request.Service
+}
+
+func NewHandler(service deletion_request.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request deletion_request.DeletionRequestRequest
+
+	if err := ctx.Bind(&request); err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	context := ctx.Request().Context()
+
+	response, err := h.service.Execute(context, request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error executing the deletion request", err)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,107 @@
+package execute_deletion_request_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/execute_deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+const requestId = "733f1ba6-1f62-4495-bf33-6f181fdf1030"
+
+func newContext(resp *httptest.ResponseRecorder) echo.Context {
+	req := httptest.NewRequest(echo.POST, "/", strings.NewReader(``))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/admin/deletion-requests/:id/execute")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(requestId)
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the deletion request", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		service.On("Execute", mock.Anything, deletion_request.DeletionRequestRequest{Id: requestId}).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusExecuted}, nil)
+
+		handler := execute_deletion_request.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), requestId)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return the business error", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		service.On("Execute", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestCannotBeExecuted)
+
+		handler := execute_deletion_request.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, "deletion request cannot be executed", he.Message.(custom_error.AppError).Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		service.On("Execute", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, assert.AnError)
+
+		handler := execute_deletion_request.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,152 @@
+package list_deletion_requests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/list_deletion_requests"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the deletion requests", func(t *testing.T) {
+		// Arrange
+		from := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)
+
+		expected := deletion_request.ListDeletionRequestsResponse{
+			Items:      []entity.DeletionRequest{{Id: "id-1", Status: entity.DeletionRequestStatusPending}},
+			Page:       2,
+			PageSize:   10,
+			TotalItems: 11,
+			TotalPages: 2,
+		}
+
+		service := deletion_request.NewMockService(t)
+
+		service.On("List", mock.Anything, deletion_request.ListDeletionRequestsRequest{
+			Status:     "pending",
+			CustomerId: "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			From:       from,
+			To:         to,
+			Page:       2,
+			PageSize:   10,
+		}).
+			Return(expected, nil)
+
+		handler := list_deletion_requests.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/?status=pending&customer_id=733f1ba6-1f62-4495-bf33-6f181fdf1030&from=2024-08-01T00:00:00Z&to=2024-08-31T00:00:00Z&page=2&page_size=10", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/admin/deletion-requests")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var res deletion_request.ListDeletionRequestsResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected.TotalItems, res.TotalItems)
+		assert.Equal(t, "id-1", res.Items[0].Id)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the date is not valid", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		handler := list_deletion_requests.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/?from=yesterday", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/admin/deletion-requests")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unprocessable entity when the filters are not valid", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		service.On("List", mock.Anything, mock.Anything).
+			Return(deletion_request.ListDeletionRequestsResponse{}, custom_error.ErrRequestNotValid)
+
+		handler := list_deletion_requests.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/?status=unknown", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/admin/deletion-requests")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := deletion_request.NewMockService(t)
+
+		service.On("List", mock.Anything, mock.Anything).
+			Return(deletion_request.ListDeletionRequestsResponse{}, assert.AnError)
+
+		handler := list_deletion_requests.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/admin/deletion-requests")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,36 @@
+package anonymous_login
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service session.Service
+}
+
+func NewHandler(service session.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	response, err := h.service.Anonymous(context)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error logging in the anonymous customer", err)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return ctx.JSON(http.StatusCreated, response)
+}
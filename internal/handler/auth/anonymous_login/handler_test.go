@@ -0,0 +1,85 @@
+package anonymous_login_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/anonymous_login"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+)
+
+func newContext(resp *httptest.ResponseRecorder) echo.Context {
+	req := httptest.NewRequest(echo.POST, "/", nil)
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/auth/anonymous")
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the tokens of the new anonymous customer", func(t *testing.T) {
+		// Arrange
+		expected := session.TokenResponse{
+			AccessToken:  "access-token",
+			TokenType:    session.TokenTypeBearer,
+			ExpiresIn:    900,
+			RefreshToken: "refresh-token",
+		}
+
+		service := session.NewMockService(t)
+
+		service.On("Anonymous", mock.Anything).
+			Return(expected, nil)
+
+		handler := anonymous_login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, "no-store", resp.Header().Get(echo.HeaderCacheControl))
+
+		var res session.TokenResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected, res)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Anonymous", mock.Anything).
+			Return(session.TokenResponse{}, assert.AnError)
+
+		handler := anonymous_login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,135 @@
+package login_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/login"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func newContext(body string, resp *httptest.ResponseRecorder) echo.Context {
+	req := httptest.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/auth/login")
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the tokens", func(t *testing.T) {
+		// Arrange
+		expected := session.TokenResponse{
+			AccessToken:  "access-token",
+			TokenType:    session.TokenTypeBearer,
+			ExpiresIn:    900,
+			RefreshToken: "refresh-token",
+		}
+
+		service := session.NewMockService(t)
+
+		service.On("Login", mock.Anything, session.LoginRequest{DocumentId: "52998224725", Password: "12345678"}).
+			Return(expected, nil)
+
+		handler := login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"12345678"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "no-store", resp.Header().Get(echo.HeaderCacheControl))
+
+		var res session.TokenResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected, res)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when the credentials are not valid", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Login", mock.Anything, mock.Anything).
+			Return(session.TokenResponse{}, custom_error.ErrInvalidCredentials)
+
+		handler := login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"wrong-password"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the body is not valid", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		handler := login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext("{", resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Login", mock.Anything, mock.Anything).
+			Return(session.TokenResponse{}, assert.AnError)
+
+		handler := login.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"12345678"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,45 @@
+package logout
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service session.Service
+}
+
+func NewHandler(service session.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request session.LogoutRequest
+
+	if err := ctx.Bind(&request); err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	context := ctx.Request().Context()
+
+	principal, err := auth.FromContext(context)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusUnauthorized, "unauthorized", err)
+	}
+
+	if err := h.service.Logout(context, principal.Subject, request); err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error logging out the customer", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
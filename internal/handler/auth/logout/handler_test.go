@@ -0,0 +1,170 @@
+package logout_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/logout"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func newContext(body string, resp *httptest.ResponseRecorder, principal *auth.Principal) echo.Context {
+	req := httptest.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	if principal != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), *principal))
+	}
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/auth/logout")
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should revoke the refresh token", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Logout", mock.Anything, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"}).
+			Return(nil)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"refresh_token":"refresh-token"}`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should revoke every refresh token of the customer", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Logout", mock.Anything, "customer-1", session.LogoutRequest{All: true}).
+			Return(nil)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"all":true}`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when there is no principal", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"refresh_token":"refresh-token"}`, resp, nil)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return the business error", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Logout", mock.Anything, mock.Anything, mock.Anything).
+			Return(custom_error.ErrInvalidRefreshToken)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"refresh_token":"refresh-token"}`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the body is not valid", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext("{", resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := session.NewMockService(t)
+
+		service.On("Logout", mock.Anything, mock.Anything, mock.Anything).
+			Return(assert.AnError)
+
+		handler := logout.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"refresh_token":"refresh-token"}`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
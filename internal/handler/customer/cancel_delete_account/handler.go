@@ -0,0 +1,39 @@
+package cancel_delete_account
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service delete_account.Service
+}
+
+func NewHandler(service delete_account.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	principal, err := auth.FromContext(context)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusUnauthorized, "unauthorized", err)
+	}
+
+	if err := h.service.Cancel(context, principal.Subject); err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error cancelling the deletion request", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
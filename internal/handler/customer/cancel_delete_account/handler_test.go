@@ -0,0 +1,142 @@
+package cancel_delete_account_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/cancel_delete_account"
+	delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should cancel the deletion request", func(t *testing.T) {
+		// Arrange
+		customerId := uuid.NewString()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Cancel", mock.Anything, customerId).
+			Return(nil)
+
+		handler := cancel_delete_account.NewHandler(service)
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: customerId})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return business error", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Cancel", mock.Anything, mock.Anything).
+			Return(custom_error.ErrDeletionRequestCannotBeCancelled)
+
+		handler := cancel_delete_account.NewHandler(service)
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusConflict,
+			Message: "deletion request cannot be cancelled",
+			Details: "only pending deletion requests can be cancelled",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Cancel", mock.Anything, mock.Anything).
+			Return(assert.AnError)
+
+		handler := cancel_delete_account.NewHandler(service)
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal error cancelling the deletion request",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		handler := cancel_delete_account.NewHandler(service)
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
 	"github.com/labstack/echo/v4"
 )
@@ -19,8 +20,13 @@ func NewHandler(service delete_account.Service) *Handler {
 }
 
 func (h *Handler) Handle(ctx echo.Context) error {
+	principal, err := auth.FromContext(ctx.Request().Context())
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusUnauthorized, "unauthorized", err)
+	}
+
 	request := delete_account.DeleteAccountRequest{
-		Id: ctx.Get("userId").(string),
+		Id: principal.Subject,
 	}
 
 	if err := ctx.Bind(&request); err != nil {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account"
 	delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
 )
 
@@ -44,7 +45,7 @@ func TestHandler_Handle(t *testing.T) {
 		e := echo.New()
 		ctx := e.NewContext(req, resp)
 		ctx.SetPath("/api/v1/customers/delete-account")
-		ctx.Set("userId", uuid.NewString())
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
 
 		// Act
 		err = handler.Handle(ctx)
@@ -81,7 +82,7 @@ func TestHandler_Handle(t *testing.T) {
 		e := echo.New()
 		ctx := e.NewContext(req, resp)
 		ctx.SetPath("/api/v1/customers/delete-account")
-		ctx.Set("userId", uuid.NewString())
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
 
 		// Act
 		err = handler.Handle(ctx)
@@ -127,7 +128,7 @@ func TestHandler_Handle(t *testing.T) {
 		e := echo.New()
 		ctx := e.NewContext(req, resp)
 		ctx.SetPath("/api/v1/customers/delete-account")
-		ctx.Set("userId", uuid.NewString())
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
 
 		// Act
 		err = handler.Handle(ctx)
@@ -146,4 +147,29 @@ func TestHandler_Handle(t *testing.T) {
 		}, he.Message)
 		service.AssertExpectations(t)
 	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		handler := delete_account.NewHandler(service)
+
+		req := httptest.NewRequest(echo.POST, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
 }
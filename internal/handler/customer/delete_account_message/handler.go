@@ -0,0 +1,61 @@
+package delete_account_message
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+)
+
+type DeleteAccountMessage struct {
+	CustomerId string `json:"customer_id"`
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Phone      string `json:"phone"`
+}
+
+type Handler struct {
+	service delete_account.Service
+}
+
+func NewHandler(service delete_account.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ProcessMessage returns an error only for the failures that may succeed on
+// a new delivery. The messages that would fail again are logged and removed
+// from the queue, instead of being retried until they reach the dead letter
+// queue.
+func (h *Handler) ProcessMessage(ctx context.Context, message string) error {
+	var body DeleteAccountMessage
+	if err := json.Unmarshal([]byte(message), &body); err != nil {
+		logger.FromContext(ctx).ErrorContext(ctx, "discarding malformed deletion request message", "error", err)
+		return nil
+	}
+
+	request := delete_account.DeleteAccountRequest{
+		Id:      body.CustomerId,
+		Name:    body.Name,
+		Address: body.Address,
+		Phone:   body.Phone,
+	}
+
+	err := h.service.Delete(ctx, request)
+
+	switch {
+	// the same message may be delivered more than once
+	case errors.Is(err, custom_error.ErrDeletionRequestAlreadyCreated):
+		logger.FromContext(ctx).InfoContext(ctx, "deletion request already created", "customer_id", body.CustomerId)
+		return nil
+	case errors.Is(err, custom_error.ErrRequestNotValid), errors.Is(err, custom_error.ErrCustomerNotFound):
+		logger.FromContext(ctx).ErrorContext(ctx, "discarding deletion request message", "customer_id", body.CustomerId, "error", err)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package delete_account_message_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account_message"
+	delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+const message = `{"customer_id":"customer-1","name":"John Doe","address":"Av. Brasil, 1000","phone":"1122334455"}`
+
+func TestHandler_ProcessMessage(t *testing.T) {
+	t.Run("Should create a deletion request from the message", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Delete", ctx, delete_account_svc.DeleteAccountRequest{
+			Id:      "customer-1",
+			Name:    "John Doe",
+			Address: "Av. Brasil, 1000",
+			Phone:   "1122334455",
+		}).
+			Return(nil)
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should ignore a duplicated message", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Delete", ctx, mock.Anything).
+			Return(custom_error.ErrDeletionRequestAlreadyCreated)
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error if the deletion request could not be created", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Delete", ctx, mock.Anything).
+			Return(errors.New("error"))
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, message)
+
+		// Assert
+		assert.Error(t, err)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should discard a message that is not valid JSON", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, "invalid")
+
+		// Assert
+		assert.NoError(t, err)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should discard a message with an invalid request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Delete", ctx, mock.Anything).
+			Return(custom_error.ErrRequestNotValid)
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, `{"customer_id":""}`)
+
+		// Assert
+		assert.NoError(t, err)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should discard a message of a customer that does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("Delete", ctx, mock.Anything).
+			Return(custom_error.ErrCustomerNotFound)
+
+		handler := delete_account_message.NewHandler(service)
+
+		// Act
+		err := handler.ProcessMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		service.AssertExpectations(t)
+	})
+}
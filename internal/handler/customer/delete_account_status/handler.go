@@ -0,0 +1,40 @@
+package delete_account_status
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service delete_account.Service
+}
+
+func NewHandler(service delete_account.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	principal, err := auth.FromContext(context)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusUnauthorized, "unauthorized", err)
+	}
+
+	response, err := h.service.GetStatus(context, principal.Subject)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error getting the deletion request", err)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,160 @@
+package delete_account_status_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account_status"
+	delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the deletion request status", func(t *testing.T) {
+		// Arrange
+		customerId := uuid.NewString()
+		now := time.Now().UTC()
+
+		expected := delete_account_svc.DeleteAccountStatusResponse{
+			Id:                    uuid.NewString(),
+			CreatedAt:             now,
+			Status:                "pending",
+			Executed:              false,
+			ExpectedExecutionDate: now.Add(time.Hour),
+		}
+
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("GetStatus", mock.Anything, customerId).
+			Return(expected, nil)
+
+		handler := delete_account_status.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: customerId})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var res delete_account_svc.DeleteAccountStatusResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected, res)
+		assert.NotContains(t, resp.Body.String(), "name")
+		assert.NotContains(t, resp.Body.String(), "address")
+		assert.NotContains(t, resp.Body.String(), "phone")
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return not found when there is no deletion request", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("GetStatus", mock.Anything, mock.Anything).
+			Return(delete_account_svc.DeleteAccountStatusResponse{}, custom_error.ErrDeletionRequestNotFound)
+
+		handler := delete_account_status.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusNotFound,
+			Message: "deletion request not found",
+			Details: "unable to find deletion request with the given customer id",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		service.On("GetStatus", mock.Anything, mock.Anything).
+			Return(delete_account_svc.DeleteAccountStatusResponse{}, assert.AnError)
+
+		handler := delete_account_status.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal error getting the deletion request",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+		service := delete_account_svc.NewMockService(t)
+
+		handler := delete_account_status.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/delete-account")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,155 @@
+package get_profile_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/get_profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the customer profile", func(t *testing.T) {
+		// Arrange
+		customerId := uuid.NewString()
+		now := time.Now().UTC()
+
+		expected := profile.ProfileResponse{
+			Id:         customerId,
+			DocumentId: "***.456.789-**",
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Version:    2,
+		}
+
+		service := profile.NewMockService(t)
+
+		service.On("Get", mock.Anything, customerId).
+			Return(expected, nil)
+
+		handler := get_profile.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/me")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: customerId})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+
+		var res profile.ProfileResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected.Id, res.Id)
+		assert.Equal(t, expected.DocumentId, res.DocumentId)
+		assert.NotContains(t, resp.Body.String(), "password")
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return not found when the customer does not exist", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		service.On("Get", mock.Anything, mock.Anything).
+			Return(profile.ProfileResponse{}, custom_error.ErrCustomerNotFound)
+
+		handler := get_profile.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/me")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		service.On("Get", mock.Anything, mock.Anything).
+			Return(profile.ProfileResponse{}, assert.AnError)
+
+		handler := get_profile.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/me")
+		ctx.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: uuid.NewString()})))
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal error getting the customer profile",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		handler := get_profile.NewHandler(service)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/api/v1/customers/me")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,134 @@
+package register_customer_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/register_customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+func newContext(body string, resp *httptest.ResponseRecorder) echo.Context {
+	req := httptest.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/customers")
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should return the customer", func(t *testing.T) {
+		// Arrange
+		expected := registration.CustomerResponse{
+			Id:        uuid.NewString(),
+			CreatedAt: time.Now().UTC(),
+		}
+
+		service := registration.NewMockService(t)
+
+		service.On("Register", mock.Anything, registration.RegisterCustomerRequest{DocumentId: "52998224725", Password: "12345678"}).
+			Return(expected, nil)
+
+		handler := register_customer.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"12345678"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+
+		var res registration.CustomerResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, expected, res)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return the business error", func(t *testing.T) {
+		// Arrange
+		service := registration.NewMockService(t)
+
+		service.On("Register", mock.Anything, mock.Anything).
+			Return(registration.CustomerResponse{}, custom_error.ErrCustomerAlreadyExists)
+
+		handler := register_customer.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"12345678"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusConflict, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the body is not valid", func(t *testing.T) {
+		// Arrange
+		service := registration.NewMockService(t)
+
+		handler := register_customer.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext("{", resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := registration.NewMockService(t)
+
+		service.On("Register", mock.Anything, mock.Anything).
+			Return(registration.CustomerResponse{}, assert.AnError)
+
+		handler := register_customer.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"document_id":"52998224725","password":"12345678"}`, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,62 @@
+package update_profile
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/etag"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service profile.Service
+}
+
+func NewHandler(service profile.Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	principal, err := auth.FromContext(context)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusUnauthorized, "unauthorized", err)
+	}
+
+	ifMatch := ctx.Request().Header.Get("If-Match")
+	if ifMatch == "" {
+		return custom_error.NewHttpAppErrorFromBusinessError(custom_error.ErrCustomerVersionRequired)
+	}
+
+	// an ETag that was never issued by us can't match the current version
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		return custom_error.NewHttpAppErrorFromBusinessError(custom_error.ErrCustomerVersionMismatch)
+	}
+
+	var request profile.UpdateProfileRequest
+
+	if err := ctx.Bind(&request); err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	request.Version = version
+
+	response, err := h.service.Update(context, principal.Subject, request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal error updating the customer profile", err)
+	}
+
+	ctx.Response().Header().Set("ETag", etag.Format(response.Version))
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,205 @@
+package update_profile_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/update_profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+const body = `{"current_password":"12345678","password":"87654321"}`
+
+func newContext(body string, ifMatch string, resp *httptest.ResponseRecorder, principal *auth.Principal) echo.Context {
+	req := httptest.NewRequest(echo.PATCH, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	if principal != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), *principal))
+	}
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/api/v1/customers/me")
+
+	return ctx
+}
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("Should update the customer profile", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		service.On("Update", mock.Anything, "customer-1", profile.UpdateProfileRequest{
+			Version:         2,
+			CurrentPassword: "12345678",
+			Password:        "87654321",
+		}).Return(profile.ProfileResponse{Id: "customer-1", Version: 3}, nil)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, `"2"`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"3"`, resp.Header().Get("ETag"))
+		assert.Contains(t, resp.Body.String(), `"id":"customer-1"`)
+		assert.NotContains(t, resp.Body.String(), "password")
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return precondition required when there is no If-Match header", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, "", resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionRequired, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return precondition failed when the If-Match header is not valid", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, "*", resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return precondition failed when the customer was changed", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		service.On("Update", mock.Anything, "customer-1", mock.Anything).
+			Return(profile.ProfileResponse{}, custom_error.ErrCustomerVersionMismatch)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, `"1"`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+		assert.Empty(t, resp.Header().Get("ETag"))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the body is not valid", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(`{"password":`, `"2"`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal error", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		service.On("Update", mock.Anything, "customer-1", mock.Anything).
+			Return(profile.ProfileResponse{}, assert.AnError)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, `"2"`, resp, &auth.Principal{Subject: "customer-1"})
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal error updating the customer profile",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+		service := profile.NewMockService(t)
+
+		handler := update_profile.NewHandler(service)
+
+		resp := httptest.NewRecorder()
+		ctx := newContext(body, `"2"`, resp, nil)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -3,33 +3,51 @@ package health
 import (
 	"net/http"
 
-	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
 	"github.com/labstack/echo/v4"
 )
 
 type Handler struct {
-	db database.DatabaseService
+	registry            *health.Registry
+	showInternalDetails bool
 }
 
-func NewHandler(db database.DatabaseService) *Handler {
+// NewHandler only sends the errors of the components to the clients when
+// showInternalDetails is set, as in development, they are always logged.
+func NewHandler(registry *health.Registry, showInternalDetails bool) *Handler {
 	return &Handler{
-		db: db,
+		registry:            registry,
+		showInternalDetails: showInternalDetails,
 	}
 }
 
-func (h *Handler) Handle(ctx echo.Context) error {
-	dbStatus := h.db.Health()
+// Live only tells the process is able to answer, the dependencies are left
+// to the readiness so an outage of one of them doesn't restart every pod.
+func (h *Handler) Live(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, health.Healthy())
+}
+
+func (h *Handler) Ready(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	report := h.registry.Check(context)
 
-	data := map[string]*health.HealthStatus{
-		"database": dbStatus,
+	for name, status := range report.Components {
+		if status.HasError() {
+			logger.FromContext(context).WarnContext(context, "health check failed", "component", name, "status", status.Status, "error", status.Err)
+		}
 	}
 
 	code := http.StatusOK
 
-	if dbStatus.HasError() {
-		code = http.StatusBadRequest
+	if !report.IsReady() {
+		code = http.StatusServiceUnavailable
+	}
+
+	if !h.showInternalDetails {
+		report = report.WithoutErrors()
 	}
 
-	return ctx.JSON(code, data)
+	return ctx.JSON(code, report)
 }
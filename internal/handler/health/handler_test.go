@@ -1,78 +1,154 @@
 package health
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
 func TestNewHandler(t *testing.T) {
 	t.Run("Should return a new handler", func(t *testing.T) {
 		// Arrange
-		db := database.NewMockDatabaseService(t)
+		registry := health.NewRegistry(time.Second, timeProvider)
 
 		// Act
-		handler := NewHandler(db)
+		handler := NewHandler(registry, true)
 
 		// Assert
 		assert.NotNil(t, handler)
 	})
 }
 
-func TestHandler_Handle(t *testing.T) {
-	t.Run("Should return a map with healthy database status", func(t *testing.T) {
+func TestHandler_Live(t *testing.T) {
+	t.Run("Should return healthy without checking the dependencies", func(t *testing.T) {
+		// Arrange
+		db := database.NewMockDatabaseService(t)
+
+		registry := health.NewRegistry(time.Second, timeProvider)
+		registry.Register("database", db)
+
+		req := httptest.NewRequest(echo.GET, "/health/live", nil)
+		resp := httptest.NewRecorder()
+
+		echo := echo.New()
+		ctx := echo.NewContext(req, resp)
+
+		handler := NewHandler(registry, true)
+
+		// Act
+		err := handler.Live(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"status":"healthy"}`, resp.Body.String())
+		db.AssertNotCalled(t, "Health", mock.Anything)
+	})
+}
+
+func TestHandler_Ready(t *testing.T) {
+	t.Run("Should return ok when the database is healthy", func(t *testing.T) {
 		// Arrange
 		db := database.NewMockDatabaseService(t)
 
-		db.On("Health").Return(&health.HealthStatus{
-			Status: "healthy",
-		}, nil)
+		db.On("Health", mock.Anything).Return(health.Healthy())
+
+		registry := health.NewRegistry(time.Second, timeProvider)
+		registry.Register("database", db)
 
-		req := httptest.NewRequest(echo.GET, "/health", nil)
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
 		resp := httptest.NewRecorder()
 
 		echo := echo.New()
 		ctx := echo.NewContext(req, resp)
 
-		handler := NewHandler(db)
+		handler := NewHandler(registry, true)
 
 		// Act
-		err := handler.Handle(ctx)
+		err := handler.Ready(ctx)
 
 		// Assert
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, resp.Code)
-		assert.JSONEq(t, `{"database": {"status":"healthy"}}`, resp.Body.String())
+		assert.Contains(t, resp.Body.String(), `"status":"healthy"`)
+		assert.Contains(t, resp.Body.String(), `"last_success_at":"2024-08-01T10:00:00Z"`)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("Should return service unavailable when the database is unhealthy", func(t *testing.T) {
+		// Arrange
+		db := database.NewMockDatabaseService(t)
+
+		db.On("Health", mock.Anything).Return(health.Unhealthy(errors.New("error")))
+
+		registry := health.NewRegistry(time.Second, timeProvider)
+		registry.Register("database", db)
+
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
+		resp := httptest.NewRecorder()
+
+		echo := echo.New()
+		ctx := echo.NewContext(req, resp)
+
+		handler := NewHandler(registry, true)
+
+		// Act
+		err := handler.Ready(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"status":"unhealthy"`)
+		assert.Contains(t, resp.Body.String(), `"err":"error"`)
+		db.AssertExpectations(t)
 	})
 
-	t.Run("Should return a map with unhealthy database status", func(t *testing.T) {
+	t.Run("Should return the components without their errors", func(t *testing.T) {
 		// Arrange
 		db := database.NewMockDatabaseService(t)
+		queue := database.NewMockDatabaseService(t)
+
+		db.On("Health", mock.Anything).Return(health.Unhealthy(errors.New("dial tcp 10.0.0.1:5432: connection refused")))
+		queue.On("Health", mock.Anything).Return(health.Healthy())
 
-		db.On("Health").Return(&health.HealthStatus{
-			Status: "unhealthy",
-			Err:    "error",
-		}, nil)
+		registry := health.NewRegistry(time.Second, timeProvider)
+		registry.Register("database", db)
+		registry.Register("queue", queue)
 
-		req := httptest.NewRequest(echo.GET, "/health", nil)
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
 		resp := httptest.NewRecorder()
 
 		echo := echo.New()
 		ctx := echo.NewContext(req, resp)
 
-		handler := NewHandler(db)
+		handler := NewHandler(registry, false)
 
 		// Act
-		err := handler.Handle(ctx)
+		err := handler.Ready(ctx)
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"database": {"status":"unhealthy", "err": "error"}}`, resp.Body.String())
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		assert.NotContains(t, resp.Body.String(), `"err"`)
+		assert.NotContains(t, resp.Body.String(), "10.0.0.1")
+		assert.Contains(t, resp.Body.String(), `"latency":`)
+		assert.Contains(t, resp.Body.String(), `"last_success_at":"2024-08-01T10:00:00Z"`)
+		db.AssertExpectations(t)
+		queue.AssertExpectations(t)
 	})
 }
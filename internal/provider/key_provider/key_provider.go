@@ -0,0 +1,117 @@
+package key_provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/encryption"
+)
+
+var (
+	ErrKeyVersionNotFound = errors.New("no master key configured for the key version")
+	ErrInvalidKeys        = errors.New("the keys must be a comma separated list of version:key")
+)
+
+// ParseKeys reads the master keys of each version, written as a comma
+// separated list of version:key, e.g. "1:key-one,2:key-two".
+func ParseKeys(value string) (map[int]string, error) {
+	keys := make(map[int]string)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		version, key, found := strings.Cut(entry, ":")
+		if !found || key == "" {
+			return nil, ErrInvalidKeys
+		}
+
+		number, err := strconv.Atoi(version)
+		if err != nil || number <= encryption.PlaintextVersion {
+			return nil, ErrInvalidKeys
+		}
+
+		if _, exists := keys[number]; exists {
+			return nil, fmt.Errorf("%w: version %d is repeated", ErrInvalidKeys, number)
+		}
+
+		keys[number] = key
+	}
+
+	return keys, nil
+}
+
+type LocalKeyProvider struct {
+	keys           map[int][]byte
+	currentVersion int
+}
+
+// NewLocalKeyProvider wraps the data keys with the given base64 encoded
+// AES-256 master keys, without depending on an external key service.
+func NewLocalKeyProvider(keys map[int]string, currentVersion int) (*LocalKeyProvider, error) {
+	decoded := make(map[int][]byte, len(keys))
+
+	for version, key := range keys {
+		value, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key of version %d: %w", version, err)
+		}
+
+		if len(value) != encryption.KeySize {
+			return nil, fmt.Errorf("master key of version %d: %w", version, encryption.ErrInvalidKeySize)
+		}
+
+		decoded[version] = value
+	}
+
+	if _, exists := decoded[currentVersion]; !exists {
+		return nil, fmt.Errorf("%w: %d", ErrKeyVersionNotFound, currentVersion)
+	}
+
+	return &LocalKeyProvider{
+		keys:           decoded,
+		currentVersion: currentVersion,
+	}, nil
+}
+
+func (p *LocalKeyProvider) CurrentVersion() int {
+	return p.currentVersion
+}
+
+func (p *LocalKeyProvider) GenerateDataKey(ctx context.Context) (provider.DataKey, error) {
+	dataKey, err := encryption.NewKey()
+	if err != nil {
+		return provider.DataKey{}, err
+	}
+
+	wrapped, err := encryption.Encrypt(p.keys[p.currentVersion], dataKey, versionData(p.currentVersion))
+	if err != nil {
+		return provider.DataKey{}, err
+	}
+
+	return provider.DataKey{
+		Plaintext: dataKey,
+		Wrapped:   wrapped,
+		Version:   p.currentVersion,
+	}, nil
+}
+
+func (p *LocalKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte, version int) ([]byte, error) {
+	key, exists := p.keys[version]
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrKeyVersionNotFound, version)
+	}
+
+	return encryption.Decrypt(key, wrapped, versionData(version))
+}
+
+func versionData(version int) []byte {
+	return []byte(strconv.Itoa(version))
+}
@@ -0,0 +1,166 @@
+package key_provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	keyOne = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("1", encryption.KeySize)))
+	keyTwo = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("2", encryption.KeySize)))
+)
+
+func TestParseKeys(t *testing.T) {
+	t.Run("Should parse the keys of each version", func(t *testing.T) {
+		// Act
+		res, err := ParseKeys("1:key-one, 2:key:two")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "key-one", 2: "key:two"}, res)
+	})
+
+	t.Run("Should return no keys if the value is empty", func(t *testing.T) {
+		// Act
+		res, err := ParseKeys("")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return error if the keys are invalid", func(t *testing.T) {
+		// Arrange
+		values := []string{
+			"key-one",
+			"1:",
+			"one:key-one",
+			"0:key-zero",
+			"-1:key",
+			"1:key-one,1:key-two",
+		}
+
+		for _, value := range values {
+			// Act
+			res, err := ParseKeys(value)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidKeys, value)
+			assert.Nil(t, res)
+		}
+	})
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	t.Run("Should return error if the current version has no key", func(t *testing.T) {
+		// Act
+		res, err := NewLocalKeyProvider(map[int]string{1: keyOne}, 2)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrKeyVersionNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if a key is not base64 encoded", func(t *testing.T) {
+		// Act
+		res, err := NewLocalKeyProvider(map[int]string{1: "not base64!"}, 1)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if a key has an invalid size", func(t *testing.T) {
+		// Act
+		res, err := NewLocalKeyProvider(map[int]string{1: base64.StdEncoding.EncodeToString([]byte("short"))}, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, encryption.ErrInvalidKeySize)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLocalKeyProvider_GenerateDataKey(t *testing.T) {
+	t.Run("Should wrap the data key with the key of the current version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		provider, err := NewLocalKeyProvider(map[int]string{1: keyOne, 2: keyTwo}, 2)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.GenerateDataKey(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, provider.CurrentVersion())
+		assert.Equal(t, 2, res.Version)
+		assert.Len(t, res.Plaintext, encryption.KeySize)
+		assert.NotEqual(t, res.Plaintext, res.Wrapped)
+
+		dataKey, err := provider.DecryptDataKey(ctx, res.Wrapped, res.Version)
+		assert.NoError(t, err)
+		assert.Equal(t, res.Plaintext, dataKey)
+	})
+}
+
+func TestLocalKeyProvider_DecryptDataKey(t *testing.T) {
+	t.Run("Should decrypt the data keys of a previous version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		previous, err := NewLocalKeyProvider(map[int]string{1: keyOne}, 1)
+		assert.NoError(t, err)
+
+		dataKey, err := previous.GenerateDataKey(ctx)
+		assert.NoError(t, err)
+
+		provider, err := NewLocalKeyProvider(map[int]string{1: keyOne, 2: keyTwo}, 2)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, dataKey.Wrapped, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, dataKey.Plaintext, res)
+	})
+
+	t.Run("Should return error if the data key is of another version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		provider, err := NewLocalKeyProvider(map[int]string{1: keyOne, 2: keyTwo}, 2)
+		assert.NoError(t, err)
+
+		dataKey, err := provider.GenerateDataKey(ctx)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, dataKey.Wrapped, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, encryption.ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the version has no key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		provider, err := NewLocalKeyProvider(map[int]string{1: keyOne}, 1)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := provider.DecryptDataKey(ctx, []byte("wrapped"), 3)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrKeyVersionNotFound)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package provider
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockKeyProvider is an autogenerated mock type for the KeyProvider type
+type MockKeyProvider struct {
+	mock.Mock
+}
+
+// CurrentVersion provides a mock function with given fields:
+func (_m *MockKeyProvider) CurrentVersion() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CurrentVersion")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// DecryptDataKey provides a mock function with given fields: ctx, wrapped, version
+func (_m *MockKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte, version int) ([]byte, error) {
+	ret := _m.Called(ctx, wrapped, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecryptDataKey")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, int) ([]byte, error)); ok {
+		return rf(ctx, wrapped, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, int) []byte); ok {
+		r0 = rf(ctx, wrapped, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, int) error); ok {
+		r1 = rf(ctx, wrapped, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateDataKey provides a mock function with given fields: ctx
+func (_m *MockKeyProvider) GenerateDataKey(ctx context.Context) (DataKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateDataKey")
+	}
+
+	var r0 DataKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (DataKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) DataKey); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(DataKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockKeyProvider creates a new instance of MockKeyProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyProvider {
+	mock := &MockKeyProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+package password_provider
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordProvider struct {
+	cost int
+}
+
+// NewPasswordProvider hashes the passwords with bcrypt, a cost out of the
+// range accepted by bcrypt falls back to the default cost.
+func NewPasswordProvider(cost int) *PasswordProvider {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &PasswordProvider{
+		cost: cost,
+	}
+}
+
+func (p *PasswordProvider) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), p.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func (p *PasswordProvider) Verify(hash string, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// IsHash tells the hashed passwords apart from the plaintext ones stored
+// before the passwords were hashed.
+func (p *PasswordProvider) IsHash(value string) bool {
+	_, err := bcrypt.Cost([]byte(value))
+	return err == nil
+}
+
+// NeedsRehash reports whether the hash was made with a different cost than
+// the configured one.
+func (p *PasswordProvider) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+
+	return cost != p.cost
+}
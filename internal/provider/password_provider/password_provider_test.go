@@ -0,0 +1,141 @@
+package password_provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNewPasswordProvider(t *testing.T) {
+	t.Run("Should use the given cost", func(t *testing.T) {
+		// Arrange
+		// Act
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Assert
+		assert.Equal(t, bcrypt.MinCost, provider.cost)
+	})
+
+	t.Run("Should use the default cost when the cost is out of range", func(t *testing.T) {
+		// Arrange
+		// Act
+		provider := NewPasswordProvider(100)
+
+		// Assert
+		assert.Equal(t, bcrypt.DefaultCost, provider.cost)
+	})
+}
+
+func TestPasswordProvider_Hash(t *testing.T) {
+	t.Run("Should hash and verify the password", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Act
+		hash, err := provider.Hash("12345678")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotEqual(t, "12345678", hash)
+		assert.True(t, provider.IsHash(hash))
+
+		valid, err := provider.Verify(hash, "12345678")
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Should return error when the password is too long", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Act
+		_, err := provider.Hash(string(make([]byte, 73)))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestPasswordProvider_Verify(t *testing.T) {
+	t.Run("Should not verify a wrong password", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		hash, err := provider.Hash("12345678")
+		assert.NoError(t, err)
+
+		// Act
+		valid, err := provider.Verify(hash, "87654321")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("Should return error when the value is not a hash", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Act
+		valid, err := provider.Verify("12345678", "12345678")
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestPasswordProvider_IsHash(t *testing.T) {
+	t.Run("Should not take a plaintext password as a hash", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Act
+		res := provider.IsHash("12345678")
+
+		// Assert
+		assert.False(t, res)
+	})
+}
+
+func TestPasswordProvider_NeedsRehash(t *testing.T) {
+	t.Run("Should not rehash when the cost is the same", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		hash, err := provider.Hash("12345678")
+		assert.NoError(t, err)
+
+		// Act
+		res := provider.NeedsRehash(hash)
+
+		// Assert
+		assert.False(t, res)
+	})
+
+	t.Run("Should rehash when the cost changed", func(t *testing.T) {
+		// Arrange
+		hash, err := NewPasswordProvider(bcrypt.MinCost).Hash("12345678")
+		assert.NoError(t, err)
+
+		provider := NewPasswordProvider(bcrypt.MinCost + 1)
+
+		// Act
+		res := provider.NeedsRehash(hash)
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should rehash a plaintext password", func(t *testing.T) {
+		// Arrange
+		provider := NewPasswordProvider(bcrypt.MinCost)
+
+		// Act
+		res := provider.NeedsRehash("12345678")
+
+		// Assert
+		assert.True(t, res)
+	})
+}
@@ -0,0 +1,116 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package provider
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockPasswordProvider is an autogenerated mock type for the PasswordProvider type
+type MockPasswordProvider struct {
+	mock.Mock
+}
+
+// Hash provides a mock function with given fields: password
+func (_m *MockPasswordProvider) Hash(password string) (string, error) {
+	ret := _m.Called(password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Hash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(password)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsHash provides a mock function with given fields: value
+func (_m *MockPasswordProvider) IsHash(value string) bool {
+	ret := _m.Called(value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsHash")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NeedsRehash provides a mock function with given fields: hash
+func (_m *MockPasswordProvider) NeedsRehash(hash string) bool {
+	ret := _m.Called(hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: hash, password
+func (_m *MockPasswordProvider) Verify(hash string, password string) (bool, error) {
+	ret := _m.Called(hash, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(hash, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(hash, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(hash, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockPasswordProvider creates a new instance of MockPasswordProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordProvider {
+	mock := &MockPasswordProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
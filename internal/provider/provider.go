@@ -1,9 +1,39 @@
 package provider
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type FuncTime func() time.Time
 
 type TimeProvider interface {
 	GetTime() time.Time
 }
+
+type PasswordProvider interface {
+	Hash(password string) (string, error)
+	Verify(hash string, password string) (bool, error)
+	IsHash(value string) bool
+	NeedsRehash(hash string) bool
+}
+
+type TokenProvider interface {
+	IssueAccessToken(subject string, issuedAt time.Time) (string, error)
+	NewRefreshToken() (string, error)
+	HashRefreshToken(token string) string
+}
+
+// DataKey is the key that encrypts a single record. Only its wrapped form is
+// stored, which needs the master key of the same version to be unwrapped.
+type DataKey struct {
+	Plaintext []byte
+	Wrapped   []byte
+	Version   int
+}
+
+type KeyProvider interface {
+	CurrentVersion() int
+	GenerateDataKey(ctx context.Context) (DataKey, error)
+	DecryptDataKey(ctx context.Context, wrapped []byte, version int) ([]byte, error)
+}
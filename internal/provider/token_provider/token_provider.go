@@ -0,0 +1,67 @@
+package token_provider
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+)
+
+const refreshTokenSize = 32
+
+var ErrSecretNotConfigured = errors.New("no secret configured to sign the tokens")
+
+type TokenProvider struct {
+	config *environment.AuthConfig
+}
+
+// NewTokenProvider signs the access tokens with the shared secret, so they
+// are accepted by the token middleware of this same service.
+func NewTokenProvider(config *environment.AuthConfig) *TokenProvider {
+	return &TokenProvider{
+		config: config,
+	}
+}
+
+func (p *TokenProvider) IssueAccessToken(subject string, issuedAt time.Time) (string, error) {
+	if !p.config.IsSecretSet() {
+		return "", ErrSecretNotConfigured
+	}
+
+	claims := jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   subject,
+		Issuer:    p.config.Issuer,
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		NotBefore: jwt.NewNumericDate(issuedAt),
+		ExpiresAt: jwt.NewNumericDate(issuedAt.Add(p.config.AccessTokenTtl)),
+	}
+
+	if p.config.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{p.config.Audience}
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(p.config.Secret))
+}
+
+// NewRefreshToken returns an opaque random token, it is not a JWT since it
+// is only ever checked against the hash stored by this service.
+func (p *TokenProvider) NewRefreshToken() (string, error) {
+	value := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(value), nil
+}
+
+func (p *TokenProvider) HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,109 @@
+package token_provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenProvider_IssueAccessToken(t *testing.T) {
+	t.Run("Should issue a token signed with the secret", func(t *testing.T) {
+		// Arrange
+		now := time.Now().Truncate(time.Second)
+
+		provider := NewTokenProvider(&environment.AuthConfig{
+			Secret:         "my-secret",
+			Issuer:         "ms-customer-management",
+			Audience:       "customers",
+			AccessTokenTtl: 15 * time.Minute,
+		})
+
+		// Act
+		res, err := provider.IssueAccessToken("customer-1", now)
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := &jwt.RegisteredClaims{}
+		_, err = jwt.ParseWithClaims(res, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte("my-secret"), nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "customer-1", claims.Subject)
+		assert.Equal(t, "ms-customer-management", claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"customers"}, claims.Audience)
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, now.Add(15*time.Minute), claims.ExpiresAt.Time)
+	})
+
+	t.Run("Should not set the audience when it is not configured", func(t *testing.T) {
+		// Arrange
+		provider := NewTokenProvider(&environment.AuthConfig{
+			Secret:         "my-secret",
+			AccessTokenTtl: 15 * time.Minute,
+		})
+
+		// Act
+		res, err := provider.IssueAccessToken("customer-1", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+
+		claims := &jwt.RegisteredClaims{}
+		_, _, err = jwt.NewParser().ParseUnverified(res, claims)
+
+		assert.NoError(t, err)
+		assert.Empty(t, claims.Audience)
+	})
+
+	t.Run("Should return an error when there is no secret", func(t *testing.T) {
+		// Arrange
+		provider := NewTokenProvider(&environment.AuthConfig{})
+
+		// Act
+		res, err := provider.IssueAccessToken("customer-1", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSecretNotConfigured)
+		assert.Empty(t, res)
+	})
+}
+
+func TestTokenProvider_NewRefreshToken(t *testing.T) {
+	t.Run("Should return a different token each time", func(t *testing.T) {
+		// Arrange
+		provider := NewTokenProvider(&environment.AuthConfig{})
+
+		// Act
+		first, err := provider.NewRefreshToken()
+		assert.NoError(t, err)
+
+		second, err := provider.NewRefreshToken()
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Len(t, first, 43)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestTokenProvider_HashRefreshToken(t *testing.T) {
+	t.Run("Should return the same hash for the same token", func(t *testing.T) {
+		// Arrange
+		provider := NewTokenProvider(&environment.AuthConfig{})
+
+		// Act
+		first := provider.HashRefreshToken("token")
+		second := provider.HashRefreshToken("token")
+		other := provider.HashRefreshToken("other-token")
+
+		// Assert
+		assert.Len(t, first, 64)
+		assert.Equal(t, first, second)
+		assert.NotEqual(t, first, other)
+	})
+}
@@ -0,0 +1,102 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package provider
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTokenProvider is an autogenerated mock type for the TokenProvider type
+type MockTokenProvider struct {
+	mock.Mock
+}
+
+// HashRefreshToken provides a mock function with given fields: token
+func (_m *MockTokenProvider) HashRefreshToken(token string) string {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HashRefreshToken")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// IssueAccessToken provides a mock function with given fields: subject, issuedAt
+func (_m *MockTokenProvider) IssueAccessToken(subject string, issuedAt time.Time) (string, error) {
+	ret := _m.Called(subject, issuedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueAccessToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (string, error)); ok {
+		return rf(subject, issuedAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
+		r0 = rf(subject, issuedAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(subject, issuedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRefreshToken provides a mock function with given fields:
+func (_m *MockTokenProvider) NewRefreshToken() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewRefreshToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTokenProvider creates a new instance of MockTokenProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenProvider {
+	mock := &MockTokenProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
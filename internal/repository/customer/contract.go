@@ -2,11 +2,18 @@ package customer
 
 import (
 	"context"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 )
 
 type Repository interface {
 	Get(ctx context.Context, id string) (entity.Customer, error)
-	Delete(ctx context.Context, id string) error
+	GetByDocument(ctx context.Context, documentId string) (entity.Customer, error)
+	ListWithPassword(ctx context.Context, afterId string, limit int) ([]entity.Customer, error)
+	Create(ctx context.Context, customer entity.Customer) error
+	Update(ctx context.Context, customer entity.Customer) error
+	ChangePassword(ctx context.Context, customer entity.Customer) error
+	Delete(ctx context.Context, id string, deletedAt time.Time) error
+	Anonymize(ctx context.Context, id string, anonymizedAt time.Time) error
 }
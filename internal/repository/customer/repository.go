@@ -3,14 +3,20 @@ package customer
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/encryption"
 )
 
 const (
-	tableName = "customers"
+	tableName                 = "customers"
+	deletionRequestsTableName = "customer_deletion_requests"
+	refreshTokensTableName    = "customer_refresh_tokens"
 )
 
 type repository struct {
@@ -24,21 +30,38 @@ func NewRepository(conn *sql.DB) Repository {
 }
 
 func (r *repository) Get(ctx context.Context, id string) (entity.Customer, error) {
+	return r.getBy(ctx, goqu.Ex{
+		"id": id,
+	})
+}
+
+// GetByDocument only looks up identified customers, the anonymous and the
+// anonymized ones have no document.
+func (r *repository) GetByDocument(ctx context.Context, documentId string) (entity.Customer, error) {
+	if documentId == "" {
+		return entity.Customer{}, custom_error.ErrCustomerNotFound
+	}
+
+	return r.getBy(ctx, goqu.Ex{
+		"document_id":  documentId,
+		"is_anonymous": false,
+	})
+}
+
+func (r *repository) getBy(ctx context.Context, conditions goqu.Ex) (entity.Customer, error) {
 	customer := entity.Customer{}
 
 	sql, params, err := goqu.
 		From(tableName).
-		Select("id", "document_id", "password", "is_anonymous", "created_at", "updated_at").
-		Where(goqu.Ex{
-			"id": id,
-		}).
+		Select(columns()...).
+		Where(conditions).
 		ToSQL()
 
 	if err != nil {
 		return entity.Customer{}, err
 	}
 
-	statement, err := r.conn.QueryContext(ctx, sql, params...)
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
 
 	if err != nil {
 		return entity.Customer{}, err
@@ -47,14 +70,7 @@ func (r *repository) Get(ctx context.Context, id string) (entity.Customer, error
 	defer statement.Close()
 
 	for statement.Next() {
-		err = statement.Scan(
-			&customer.Id,
-			&customer.DocumentId,
-			&customer.Password,
-			&customer.IsAnonymous,
-			&customer.CreatedAt,
-			&customer.UpdatedAt)
-
+		customer, err = scanCustomer(statement)
 		if err != nil {
 			return entity.Customer{}, err
 		}
@@ -67,7 +83,183 @@ func (r *repository) Get(ctx context.Context, id string) (entity.Customer, error
 	return customer, nil
 }
 
-func (r *repository) Delete(ctx context.Context, id string) error {
+// ListWithPassword returns the customers after the given id that have a
+// password, hashed or not, telling them apart is up to the password provider.
+func (r *repository) ListWithPassword(ctx context.Context, afterId string, limit int) ([]entity.Customer, error) {
+	sql, params, err := goqu.
+		From(tableName).
+		Select(columns()...).
+		Where(
+			goqu.C("password").Neq(""),
+			goqu.C("id").Gt(afterId),
+		).
+		Order(goqu.C("id").Asc()).
+		Limit(uint(limit)).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer statement.Close()
+
+	customers := make([]entity.Customer, 0, limit)
+
+	for statement.Next() {
+		customer, err := scanCustomer(statement)
+		if err != nil {
+			return nil, err
+		}
+
+		customers = append(customers, customer)
+	}
+
+	if err := statement.Err(); err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func columns() []interface{} {
+	return []interface{}{
+		"id",
+		"document_id",
+		goqu.COALESCE(goqu.C("document_type"), document.TypeUnknown).As("document_type"),
+		"password",
+		"is_anonymous",
+		"version",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func scanCustomer(rows *sql.Rows) (entity.Customer, error) {
+	customer := entity.Customer{}
+
+	err := rows.Scan(
+		&customer.Id,
+		&customer.DocumentId,
+		&customer.DocumentType,
+		&customer.Password,
+		&customer.IsAnonymous,
+		&customer.Version,
+		&customer.CreatedAt,
+		&customer.UpdatedAt)
+
+	return customer, err
+}
+
+func (r *repository) Create(ctx context.Context, customer entity.Customer) error {
+	sql, params, err := goqu.Insert(tableName).
+		Rows(goqu.Record{
+			"id":            customer.Id,
+			"document_id":   customer.DocumentId,
+			"document_type": customer.DocumentType,
+			"password":      customer.Password,
+			"is_anonymous":  customer.IsAnonymous,
+			"version":       customer.Version,
+			"created_at":    customer.CreatedAt,
+			"updated_at":    customer.UpdatedAt,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if _, err := database.ExecContext(ctx, r.conn, tableName, sql, params...); err != nil {
+		if database.IsUniqueViolation(err) {
+			return custom_error.ErrCustomerAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Update only succeeds when the customer is still at the version it was
+// read, so concurrent changes do not overwrite each other. The version of
+// the customer is incremented.
+func (r *repository) Update(ctx context.Context, customer entity.Customer) error {
+	return update(ctx, r.conn, customer)
+}
+
+// ChangePassword updates the customer as Update does and revokes its
+// sessions, in the same transaction, so a password is never changed while
+// the sessions opened with the previous one are still valid.
+func (r *repository) ChangePassword(ctx context.Context, customer entity.Customer) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := update(ctx, tx, customer); err != nil {
+		return err
+	}
+
+	if err := revokeRefreshTokens(ctx, tx, customer.Id, customer.UpdatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func update(ctx context.Context, conn database.Execer, customer entity.Customer) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"password":   customer.Password,
+			"version":    goqu.L("version + 1"),
+			"updated_at": customer.UpdatedAt,
+		}).
+		Where(goqu.Ex{
+			"id":      customer.Id,
+			"version": customer.Version,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrCustomerVersionMismatch
+	}
+
+	return nil
+}
+
+// Delete removes the customer, its sessions go along with it. The deletion
+// requests are kept with the id of the customer, so their personal data is
+// scrubbed as well.
+func (r *repository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := scrubDeletionRequests(ctx, tx, id, deletedAt); err != nil {
+		return err
+	}
+
 	sql, params, err := goqu.
 		Delete(tableName).
 		Where(goqu.Ex{
@@ -79,7 +271,7 @@ func (r *repository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	result, err := r.conn.ExecContext(ctx, sql, params...)
+	result, err := database.ExecContext(ctx, tx, tableName, sql, params...)
 
 	if err != nil {
 		return err
@@ -95,5 +287,104 @@ func (r *repository) Delete(ctx context.Context, id string) error {
 		return custom_error.ErrCustomerNotFound
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+// Anonymize scrubs the personal data of the customer, and of its deletion
+// requests, but keeps the customer id so the references held by other
+// services are still valid. The sessions of the customer are revoked.
+func (r *repository) Anonymize(ctx context.Context, id string, anonymizedAt time.Time) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"document_id":   "",
+			"document_type": document.TypeUnknown,
+			"password":      "",
+			"is_anonymous":  true,
+			"version":       goqu.L("version + 1"),
+			"updated_at":    anonymizedAt,
+		}).
+		Where(goqu.Ex{
+			"id": id,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, tx, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrCustomerNotFound
+	}
+
+	if err := scrubDeletionRequests(ctx, tx, id, anonymizedAt); err != nil {
+		return err
+	}
+
+	if err := revokeRefreshTokens(ctx, tx, id, anonymizedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func scrubDeletionRequests(ctx context.Context, tx *sql.Tx, customerId string, scrubbedAt time.Time) error {
+	sql, params, err := goqu.
+		Update(deletionRequestsTableName).
+		Set(goqu.Record{
+			"name":        "",
+			"address":     "",
+			"phone":       "",
+			"data_key":    "",
+			"key_version": encryption.PlaintextVersion,
+			"updated_at":  scrubbedAt,
+		}).
+		Where(goqu.Ex{
+			"customer_id": customerId,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = database.ExecContext(ctx, tx, deletionRequestsTableName, sql, params...)
+
+	return err
+}
+
+func revokeRefreshTokens(ctx context.Context, tx *sql.Tx, customerId string, revokedAt time.Time) error {
+	sql, params, err := goqu.
+		Update(refreshTokensTableName).
+		Set(goqu.Record{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).
+		Where(
+			goqu.C("customer_id").Eq(customerId),
+			goqu.C("revoked_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = database.ExecContext(ctx, tx, refreshTokensTableName, sql, params...)
+
+	return err
 }
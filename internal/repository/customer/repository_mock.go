@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -14,17 +16,71 @@ type MockRepository struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *MockRepository) Delete(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// Anonymize provides a mock function with given fields: ctx, id, anonymizedAt
+func (_m *MockRepository) Anonymize(ctx context.Context, id string, anonymizedAt time.Time) error {
+	ret := _m.Called(ctx, id, anonymizedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, anonymizedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx, customer
+func (_m *MockRepository) ChangePassword(ctx context.Context, customer entity.Customer) error {
+	ret := _m.Called(ctx, customer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Customer) error); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, customer
+func (_m *MockRepository) Create(ctx context.Context, customer entity.Customer) error {
+	ret := _m.Called(ctx, customer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Customer) error); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id, deletedAt
+func (_m *MockRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	ret := _m.Called(ctx, id, deletedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,6 +116,82 @@ func (_m *MockRepository) Get(ctx context.Context, id string) (entity.Customer,
 	return r0, r1
 }
 
+// GetByDocument provides a mock function with given fields: ctx, documentId
+func (_m *MockRepository) GetByDocument(ctx context.Context, documentId string) (entity.Customer, error) {
+	ret := _m.Called(ctx, documentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByDocument")
+	}
+
+	var r0 entity.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Customer, error)); ok {
+		return rf(ctx, documentId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Customer); ok {
+		r0 = rf(ctx, documentId)
+	} else {
+		r0 = ret.Get(0).(entity.Customer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, documentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWithPassword provides a mock function with given fields: ctx, afterId, limit
+func (_m *MockRepository) ListWithPassword(ctx context.Context, afterId string, limit int) ([]entity.Customer, error) {
+	ret := _m.Called(ctx, afterId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithPassword")
+	}
+
+	var r0 []entity.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]entity.Customer, error)); ok {
+		return rf(ctx, afterId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []entity.Customer); ok {
+		r0 = rf(ctx, afterId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Customer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, customer
+func (_m *MockRepository) Update(ctx context.Context, customer entity.Customer) error {
+	ret := _m.Called(ctx, customer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Customer) error); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
@@ -8,8 +8,12 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,8 +25,8 @@ func TestRepository_Get(t *testing.T) {
 		defer db.Close()
 
 		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "password", "is_anonymous", "created_at", "updated_at"}).
-				AddRow("id", "document_id", "password", true, time.Now(), time.Now()))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}).
+				AddRow("id", "document_id", 0, "password", true, 1, time.Now(), time.Now()))
 
 		config := &environment.Config{
 			DbConfig: &environment.DatabaseConfig{
@@ -78,7 +82,7 @@ func TestRepository_Get(t *testing.T) {
 		defer db.Close()
 
 		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "password", "is_anonymous", "created_at", "updated_at"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}))
 
 		config := &environment.Config{
 			DbConfig: &environment.DatabaseConfig{
@@ -100,32 +104,103 @@ func TestRepository_Get(t *testing.T) {
 	})
 }
 
-func TestRepository_Delete(t *testing.T) {
-	t.Run("Should delete a customer", func(t *testing.T) {
+func TestRepository_GetByDocument(t *testing.T) {
+	t.Run("Should return a customer", func(t *testing.T) {
 		// Arrange
 		db, mock, err := sqlmock.New()
 		assert.NoError(t, err)
 		defer db.Close()
 
-		mock.ExpectExec("DELETE FROM (.+)?customers(.+)?").
-			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)? WHERE (.+)?document_id(.+)?is_anonymous(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}).
+				AddRow("id", "52998224725", 1, "password", false, 1, time.Now(), time.Now()))
 
-		config := &environment.Config{
-			DbConfig: &environment.DatabaseConfig{
-				Url: "postgres://host:1234",
-			},
-		}
+		repo := customer.NewRepository(db)
 
-		service := database.NewDatabase(config)
-		service.(*database.Service).Client = db
+		// Act
+		res, err := repo.GetByDocument(context.Background(), "52998224725")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "id", res.Id)
+		assert.Equal(t, "52998224725", res.DocumentId)
+		assert.Equal(t, document.TypeCPF, res.DocumentType)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the customer is not found", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		res, err := repo.GetByDocument(context.Background(), "52998224725")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should not look up an empty document", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		res, err := repo.GetByDocument(context.Background(), "")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		assert.Empty(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Run("Should create a customer", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		repo := customer.NewRepository(db)
 
 		// Act
-		err = repo.Delete(context.Background(), "id")
+		err = repo.Create(context.Background(), entity.NewCustomer("52998224725", document.TypeCPF, "password", time.Now()))
 
 		// Assert
 		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the document is already registered", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO (.+)?customers(.+)?").
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Create(context.Background(), entity.NewCustomer("52998224725", document.TypeCPF, "password", time.Now()))
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerAlreadyExists)
 	})
 
 	t.Run("Should return an error", func(t *testing.T) {
@@ -134,25 +209,277 @@ func TestRepository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectExec("INSERT INTO (.+)?customers(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Create(context.Background(), entity.NewAnonymousCustomer(time.Now()))
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, custom_error.ErrCustomerAlreadyExists)
+	})
+}
+
+func TestRepository_ListWithPassword(t *testing.T) {
+	t.Run("Should return the customers with a password after the given id", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT (.+) FROM (.+)?customers(.+)? WHERE \(\("password" != ''\) AND \("id" > 'id-0'\)\) ORDER BY "id" ASC LIMIT 10`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}).
+				AddRow("id-1", "52998224725", 1, "12345678", false, 1, time.Now(), time.Now()).
+				AddRow("id-2", "11222333000181", 2, "87654321", false, 1, time.Now(), time.Now()))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		res, err := repo.ListWithPassword(context.Background(), "id-0", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "id-1", res[0].Id)
+		assert.Equal(t, "12345678", res[0].Password)
+		assert.Equal(t, "id-2", res[1].Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an empty list", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_id", "document_type", "password", "is_anonymous", "version", "created_at", "updated_at"}))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		res, err := repo.ListWithPassword(context.Background(), "id-0", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customers(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		res, err := repo.ListWithPassword(context.Background(), "id-0", 10)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepository_Update(t *testing.T) {
+	t.Run("Should update a customer", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customers(.+)? SET (.+)?password(.+)?version(.+)? WHERE (.+)?version(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Update(context.Background(), entity.Customer{Id: "id", Password: "$2a$12$hash", Version: 1, UpdatedAt: time.Now()})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the version does not match", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Update(context.Background(), entity.Customer{Id: "id", Password: "$2a$12$hash", Version: 1, UpdatedAt: time.Now()})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerVersionMismatch)
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Update(context.Background(), entity.Customer{Id: "id", Password: "$2a$12$hash", Version: 1, UpdatedAt: time.Now()})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_ChangePassword(t *testing.T) {
+	t.Run("Should change the password and revoke the refresh tokens", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)? SET (.+)?password(.+)?version(.+)? WHERE (.+)?version(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)? SET (.+)?revoked_at(.+)? WHERE (.+)?customer_id(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.ChangePassword(context.Background(), entity.Customer{Id: "id", Password: "$2a$12$hash", Version: 1, UpdatedAt: time.Now()})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the version does not match", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.ChangePassword(context.Background(), entity.Customer{Id: "id", Version: 1})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerVersionMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should keep the password when the refresh tokens could not be revoked", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.ChangePassword(context.Background(), entity.Customer{Id: "id", Version: 1})
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	t.Run("Should delete a customer and scrub its deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?"address"=''(.+)?"data_key"=''(.+)?"key_version"=0(.+)?"name"=''(.+)?"phone"=''(.+)? WHERE (.+)?customer_id(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectExec("DELETE FROM (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Delete(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to scrub the deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
 			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
 
-		config := &environment.Config{
-			DbConfig: &environment.DatabaseConfig{
-				Url: "postgres://host:1234",
-			},
-		}
+		repo := customer.NewRepository(db)
 
-		service := database.NewDatabase(config)
-		service.(*database.Service).Client = db
+		// Act
+		err = repo.Delete(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM (.+)?customers(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
 
 		repo := customer.NewRepository(db)
 
 		// Act
-		err = repo.Delete(context.Background(), "id")
+		err = repo.Delete(context.Background(), "id", time.Now())
 
 		// Assert
 		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("Should return an error when the customer is not found", func(t *testing.T) {
@@ -161,22 +488,196 @@ func TestRepository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
 		mock.ExpectExec("DELETE FROM (.+)?customers(.+)?").
 			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
 
-		config := &environment.Config{
-			DbConfig: &environment.DatabaseConfig{
-				Url: "postgres://host:1234",
-			},
-		}
+		repo := customer.NewRepository(db)
 
-		service := database.NewDatabase(config)
-		service.(*database.Service).Client = db
+		// Act
+		err = repo.Delete(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_Anonymize(t *testing.T) {
+	t.Run("Should anonymize a customer", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)? SET (.+)?is_anonymous(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?key_version(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)? SET (.+)?revoked_at(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the customer is not found", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to begin a transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin().WillReturnError(errors.New("error"))
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to anonymize the customer", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to get the affected rows", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to scrub the deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to revoke the refresh tokens", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := customer.NewRepository(db)
+
+		// Act
+		err = repo.Anonymize(context.Background(), "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to commit", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customers(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit().WillReturnError(errors.New("error"))
 
 		repo := customer.NewRepository(db)
 
 		// Act
-		err = repo.Delete(context.Background(), "id")
+		err = repo.Anonymize(context.Background(), "id", time.Now())
 
 		// Assert
 		assert.Error(t, err)
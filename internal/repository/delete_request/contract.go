@@ -2,11 +2,40 @@ package delete_request
 
 import (
 	"context"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 )
 
+type Filter struct {
+	Status     entity.DeletionRequestStatus
+	CustomerId string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+// ReencryptResult is where a batch stopped, to continue the next one after
+// it, and how many requests were moved to the current key version or skipped
+// because they could not be decrypted.
+type ReencryptResult struct {
+	LastId      string
+	Read        int
+	Reencrypted int
+	Skipped     int
+}
+
 type Repository interface {
+	List(ctx context.Context, filter Filter) ([]entity.DeletionRequest, int, error)
+	GetById(ctx context.Context, id string) (entity.DeletionRequest, error)
 	GetByCustomerId(ctx context.Context, customerId string) (entity.DeletionRequest, error)
-	Create(ctx context.Context, request entity.DeletionRequest) error
+	Create(ctx context.Context, request entity.DeletionRequest, message entity.OutboxMessage) error
+	ClaimPending(ctx context.Context, now time.Time, lockedUntil time.Time, limit int, maxAttempts int) ([]entity.DeletionRequest, error)
+	ClaimById(ctx context.Context, id string, now time.Time, lockedUntil time.Time) (entity.DeletionRequest, error)
+	Update(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time) error
+	MarkAsExecuted(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time, message entity.OutboxMessage) error
+	Cancel(ctx context.Context, id string, cancelledAt time.Time) error
+	Reject(ctx context.Context, id string, reason string, rejectedAt time.Time) error
+	Reencrypt(ctx context.Context, afterId string, limit int) (ReencryptResult, error)
 }
@@ -3,42 +3,210 @@ package delete_request
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/encryption"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
 )
 
 const (
 	tableName = "customer_deletion_requests"
+
+	interruptedError = "the execution was interrupted before it finished"
 )
 
+var columns = []interface{}{
+	"id",
+	"customer_id",
+	"name",
+	"address",
+	"phone",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"executed_at",
+	"cancelled_at",
+	"rejected_at",
+	"rejection_reason",
+	"scheduled_for",
+	"created_at",
+	"updated_at",
+	"data_key",
+	"key_version",
+}
+
+// claimedColumns leave the personal data out of the claimed requests, the
+// execution does not need it and would otherwise decrypt every claimed row.
+var claimedColumns = []interface{}{
+	"id",
+	"customer_id",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"executed_at",
+	"cancelled_at",
+	"rejected_at",
+	"rejection_reason",
+	"scheduled_for",
+	"created_at",
+	"updated_at",
+}
+
+func selectColumns() []interface{} {
+	return withCustomerId(columns)
+}
+
+func selectClaimedColumns() []interface{} {
+	return withCustomerId(claimedColumns)
+}
+
+// withCustomerId reads a missing customer as an empty id, the reference was
+// cleared when the customer was deleted before the requests kept it.
+func withCustomerId(columns []interface{}) []interface{} {
+	selected := make([]interface{}, 0, len(columns))
+
+	for _, column := range columns {
+		if column == "customer_id" {
+			selected = append(selected, goqu.COALESCE(goqu.C("customer_id"), "").As("customer_id"))
+			continue
+		}
+
+		selected = append(selected, column)
+	}
+
+	return selected
+}
+
 type repository struct {
-	conn *sql.DB
+	conn     *sql.DB
+	envelope *encryption.Envelope
 }
 
-func NewRepository(conn *sql.DB) Repository {
+// NewRepository encrypts the name, address and phone of the requests, each
+// request with a data key of its own wrapped by the key provider.
+func NewRepository(conn *sql.DB, keyProvider provider.KeyProvider) Repository {
 	return &repository{
-		conn: conn,
+		conn:     conn,
+		envelope: encryption.NewEnvelope(keyProvider),
 	}
 }
 
+func (r *repository) List(ctx context.Context, filter Filter) ([]entity.DeletionRequest, int, error) {
+	conditions := filterConditions(filter)
+
+	countSql, countParams, err := goqu.
+		From(tableName).
+		Select(goqu.COUNT("*")).
+		Where(conditions...).
+		ToSQL()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := database.QueryRowContext(ctx, r.conn, tableName, countSql, countParams...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []entity.DeletionRequest{}, 0, nil
+	}
+
+	sql, params, err := goqu.
+		From(tableName).
+		Select(selectColumns()...).
+		Where(conditions...).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Asc()).
+		Limit(uint(filter.Limit)).
+		Offset(uint(filter.Offset)).
+		ToSQL()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer statement.Close()
+
+	requests := make([]entity.DeletionRequest, 0, filter.Limit)
+
+	for statement.Next() {
+		request, err := r.scan(ctx, statement)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err := statement.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string) (entity.DeletionRequest, error) {
+	sql, params, err := goqu.
+		From(tableName).
+		Select(selectColumns()...).
+		Where(goqu.Ex{
+			"id": id,
+		}).
+		ToSQL()
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	defer statement.Close()
+
+	if !statement.Next() {
+		if err := statement.Err(); err != nil {
+			return entity.DeletionRequest{}, err
+		}
+
+		return entity.DeletionRequest{}, custom_error.ErrDeletionRequestNotFound
+	}
+
+	return r.scan(ctx, statement)
+}
+
 func (r *repository) GetByCustomerId(ctx context.Context, customerId string) (entity.DeletionRequest, error) {
 	deletionRequest := entity.DeletionRequest{}
 
 	sql, params, err := goqu.
 		From(tableName).
-		Select("id", "customer_id", "name", "address", "phone", "executed", "created_at", "updated_at").
+		Select(selectColumns()...).
 		Where(goqu.Ex{
 			"customer_id": customerId,
-			"executed":    false,
 		}).
+		Order(goqu.C("created_at").Desc()).
+		Limit(1).
 		ToSQL()
 	if err != nil {
 		return entity.DeletionRequest{}, err
 	}
 
-	statement, err := r.conn.QueryContext(ctx, sql, params...)
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
 
 	if err != nil {
 		return entity.DeletionRequest{}, err
@@ -47,16 +215,7 @@ func (r *repository) GetByCustomerId(ctx context.Context, customerId string) (en
 	defer statement.Close()
 
 	for statement.Next() {
-		err = statement.Scan(
-			&deletionRequest.Id,
-			&deletionRequest.CustomerId,
-			&deletionRequest.Name,
-			&deletionRequest.Address,
-			&deletionRequest.Phone,
-			&deletionRequest.Executed,
-			&deletionRequest.CreatedAt,
-			&deletionRequest.UpdatedAt)
-
+		deletionRequest, err = r.scan(ctx, statement)
 		if err != nil {
 			return entity.DeletionRequest{}, err
 		}
@@ -69,30 +228,58 @@ func (r *repository) GetByCustomerId(ctx context.Context, customerId string) (en
 	return deletionRequest, nil
 }
 
-func (r *repository) Create(ctx context.Context, request entity.DeletionRequest) error {
+func (r *repository) Create(ctx context.Context, request entity.DeletionRequest, message entity.OutboxMessage) error {
 	tx, err := r.conn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer tx.Rollback()
+
+	sealed, err := r.envelope.Seal(ctx, request.Id, request.Name, request.Address, request.Phone)
+	if err != nil {
+		return err
+	}
+
 	sql, params, err := goqu.Insert(tableName).
-		Cols("id", "customer_id", "name", "address", "phone", "executed", "created_at", "updated_at").
+		Cols(columns...).
 		Vals(goqu.Vals{
 			request.Id,
 			request.CustomerId,
-			request.Name,
-			request.Address,
-			request.Phone,
-			request.Executed,
+			sealed.Values[0],
+			sealed.Values[1],
+			sealed.Values[2],
+			request.Status,
+			request.Attempts,
+			request.LastError,
+			request.NextAttemptAt,
+			request.LockedUntil,
+			request.ExecutedAt,
+			request.CancelledAt,
+			request.RejectedAt,
+			request.RejectionReason,
+			request.ScheduledFor,
 			request.CreatedAt,
 			request.UpdatedAt,
+			sealed.DataKey,
+			sealed.KeyVersion,
 		}).
 		ToSQL()
 	if err != nil {
 		return err
 	}
 
-	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+	if _, err := database.ExecContext(ctx, tx, tableName, sql, params...); err != nil {
+		// a concurrent delivery of the same message created the request
+		// between the check of the service and this insert
+		if database.IsUniqueViolation(err) {
+			return custom_error.ErrDeletionRequestAlreadyCreated
+		}
+
+		return err
+	}
+
+	if err := outbox.Insert(ctx, tx, message); err != nil {
 		return err
 	}
 
@@ -102,3 +289,486 @@ func (r *repository) Create(ctx context.Context, request entity.DeletionRequest)
 
 	return nil
 }
+
+// ClaimPending claims the requests due to be executed. The ones interrupted
+// by the last of their attempts are flagged as failed instead, and returned
+// as such to be reported without being executed again.
+func (r *repository) ClaimPending(ctx context.Context, now time.Time, lockedUntil time.Time, limit int, maxAttempts int) ([]entity.DeletionRequest, error) {
+	// requests left in executing with an expired lock belong to a replica
+	// that died in the middle of the execution, so they are claimed again and
+	// the interrupted execution counts as an attempt
+	pending := goqu.
+		From(tableName).
+		Select("id").
+		Where(goqu.Or(
+			goqu.And(
+				goqu.C("status").Eq(entity.DeletionRequestStatusPending),
+				goqu.C("scheduled_for").Lte(now),
+				goqu.C("next_attempt_at").Lte(now),
+			),
+			goqu.And(
+				goqu.C("status").Eq(entity.DeletionRequestStatusExecuting),
+				goqu.C("locked_until").Lte(now),
+			),
+		)).
+		Order(goqu.C("next_attempt_at").Asc()).
+		Limit(uint(limit)).
+		ForUpdate(exp.SkipLocked)
+
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status": goqu.Case().
+				When(exhausted(maxAttempts), entity.DeletionRequestStatusFailed).
+				Else(entity.DeletionRequestStatusExecuting),
+			"attempts": reclaimedAttempts(),
+			"last_error": goqu.Case().
+				When(exhausted(maxAttempts), interruptedError).
+				Else(goqu.C("last_error")),
+			"locked_until": lockedUntil,
+			"updated_at":   now,
+		}).
+		Where(goqu.C("id").In(pending)).
+		Returning(selectClaimedColumns()...).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer statement.Close()
+
+	requests := make([]entity.DeletionRequest, 0, limit)
+
+	for statement.Next() {
+		request, err := scanClaimed(statement)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, statement.Err()
+}
+
+// ClaimById claims a single request to be executed right away, ignoring the
+// grace period and the retries backoff. As the pending ones, it is claimed
+// without the personal data.
+func (r *repository) ClaimById(ctx context.Context, id string, now time.Time, lockedUntil time.Time) (entity.DeletionRequest, error) {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status":       entity.DeletionRequestStatusExecuting,
+			"attempts":     reclaimedAttempts(),
+			"locked_until": lockedUntil,
+			"updated_at":   now,
+		}).
+		Where(
+			goqu.C("id").Eq(id),
+			goqu.Or(
+				goqu.C("status").In(entity.DeletionRequestStatusPending, entity.DeletionRequestStatusFailed),
+				goqu.And(
+					goqu.C("status").Eq(entity.DeletionRequestStatusExecuting),
+					goqu.C("locked_until").Lte(now),
+				),
+			),
+		).
+		Returning(selectClaimedColumns()...).
+		ToSQL()
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	defer statement.Close()
+
+	if !statement.Next() {
+		if err := statement.Err(); err != nil {
+			return entity.DeletionRequest{}, err
+		}
+
+		return entity.DeletionRequest{}, custom_error.ErrDeletionRequestCannotBeExecuted
+	}
+
+	return scanClaimed(statement)
+}
+
+// Update saves the outcome of an execution, as long as the request is still
+// claimed with the lock returned by the claim. Otherwise the lock expired and
+// another execution claimed the request in the meantime.
+func (r *repository) Update(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time) error {
+	return update(ctx, r.conn, request, claimedUntil)
+}
+
+// MarkAsExecuted saves the executed request together with the message that
+// notifies the execution, with the same ownership check of Update.
+func (r *repository) MarkAsExecuted(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time, message entity.OutboxMessage) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := update(ctx, tx, request, claimedUntil); err != nil {
+		return err
+	}
+
+	if err := outbox.Insert(ctx, tx, message); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) Cancel(ctx context.Context, id string, cancelledAt time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status":       entity.DeletionRequestStatusCancelled,
+			"cancelled_at": cancelledAt,
+			"updated_at":   cancelledAt,
+		}).
+		Where(goqu.Ex{
+			"id":     id,
+			"status": entity.DeletionRequestStatusPending,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrDeletionRequestCannotBeCancelled
+	}
+
+	return nil
+}
+
+func (r *repository) Reject(ctx context.Context, id string, reason string, rejectedAt time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status":           entity.DeletionRequestStatusRejected,
+			"rejection_reason": reason,
+			"rejected_at":      rejectedAt,
+			"updated_at":       rejectedAt,
+		}).
+		Where(goqu.Ex{
+			"id":     id,
+			"status": entity.DeletionRequestStatusPending,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrDeletionRequestCannotBeRejected
+	}
+
+	return nil
+}
+
+// Reencrypt moves the requests after the given id that are behind the
+// current key version, including the ones stored before the encryption, to
+// it. The versions ahead of it are left alone in case of a rollback of the
+// rotation. No lock is held while the data keys are decrypted
+// and generated: each request is only updated if it still has the values it
+// was read with, otherwise it was changed in the meantime and is left as is.
+// The data key alone is not enough, the requests scrubbed in the meantime have
+// the same empty data key of the ones stored before the encryption.
+// The requests that cannot be decrypted are logged and skipped, so they do not
+// block the ones after them.
+func (r *repository) Reencrypt(ctx context.Context, afterId string, limit int) (ReencryptResult, error) {
+	records, err := listOutdated(ctx, r.conn, afterId, r.envelope.CurrentVersion(), limit)
+	if err != nil {
+		return ReencryptResult{}, err
+	}
+
+	result := ReencryptResult{
+		Read: len(records),
+	}
+
+	for _, record := range records {
+		result.LastId = record.id
+
+		values, err := r.envelope.Open(ctx, record.id, record.sealed)
+		if err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "deletion request could not be decrypted, skipping it", "id", record.id, "key_version", record.sealed.KeyVersion, "error", err)
+			result.Skipped++
+			continue
+		}
+
+		sealed, err := r.envelope.Seal(ctx, record.id, values...)
+		if err != nil {
+			return result, err
+		}
+
+		sql, params, err := goqu.
+			Update(tableName).
+			Set(goqu.Record{
+				"name":        sealed.Values[0],
+				"address":     sealed.Values[1],
+				"phone":       sealed.Values[2],
+				"data_key":    sealed.DataKey,
+				"key_version": sealed.KeyVersion,
+			}).
+			Where(goqu.Ex{
+				"id":          record.id,
+				"name":        record.sealed.Values[0],
+				"address":     record.sealed.Values[1],
+				"phone":       record.sealed.Values[2],
+				"data_key":    record.sealed.DataKey,
+				"key_version": record.sealed.KeyVersion,
+			}).
+			ToSQL()
+		if err != nil {
+			return result, err
+		}
+
+		updated, err := database.ExecContext(ctx, r.conn, tableName, sql, params...)
+		if err != nil {
+			return result, err
+		}
+
+		rowsAffected, err := updated.RowsAffected()
+		if err != nil {
+			return result, err
+		}
+
+		result.Reencrypted += int(rowsAffected)
+	}
+
+	return result, nil
+}
+
+type sealedRecord struct {
+	id     string
+	sealed encryption.Sealed
+}
+
+func listOutdated(ctx context.Context, conn *sql.DB, afterId string, currentVersion int, limit int) ([]sealedRecord, error) {
+	sql, params, err := goqu.
+		From(tableName).
+		Select("id", "name", "address", "phone", "data_key", "key_version").
+		Where(
+			goqu.C("key_version").Lt(currentVersion),
+			goqu.C("id").Gt(afterId),
+		).
+		Order(goqu.C("id").Asc()).
+		Limit(uint(limit)).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := database.QueryContext(ctx, conn, tableName, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer statement.Close()
+
+	records := make([]sealedRecord, 0, limit)
+
+	for statement.Next() {
+		record := sealedRecord{
+			sealed: encryption.Sealed{
+				Values: make([]string, 3),
+			},
+		}
+
+		err := statement.Scan(
+			&record.id,
+			&record.sealed.Values[0],
+			&record.sealed.Values[1],
+			&record.sealed.Values[2],
+			&record.sealed.DataKey,
+			&record.sealed.KeyVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, statement.Err()
+}
+
+func filterConditions(filter Filter) []exp.Expression {
+	conditions := make([]exp.Expression, 0, 4)
+
+	if filter.Status != "" {
+		conditions = append(conditions, goqu.C("status").Eq(filter.Status))
+	}
+
+	if filter.CustomerId != "" {
+		conditions = append(conditions, goqu.C("customer_id").Eq(filter.CustomerId))
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, goqu.C("created_at").Gte(filter.From))
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, goqu.C("created_at").Lte(filter.To))
+	}
+
+	return conditions
+}
+
+func (r *repository) scan(ctx context.Context, rows *sql.Rows) (entity.DeletionRequest, error) {
+	request := entity.DeletionRequest{}
+	sealed := encryption.Sealed{
+		Values: make([]string, 3),
+	}
+
+	err := rows.Scan(
+		&request.Id,
+		&request.CustomerId,
+		&sealed.Values[0],
+		&sealed.Values[1],
+		&sealed.Values[2],
+		&request.Status,
+		&request.Attempts,
+		&request.LastError,
+		&request.NextAttemptAt,
+		&request.LockedUntil,
+		&request.ExecutedAt,
+		&request.CancelledAt,
+		&request.RejectedAt,
+		&request.RejectionReason,
+		&request.ScheduledFor,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&sealed.DataKey,
+		&sealed.KeyVersion)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	values, err := r.envelope.Open(ctx, request.Id, sealed)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	request.Name, request.Address, request.Phone = values[0], values[1], values[2]
+
+	return request, nil
+}
+
+func scanClaimed(rows *sql.Rows) (entity.DeletionRequest, error) {
+	request := entity.DeletionRequest{}
+
+	err := rows.Scan(
+		&request.Id,
+		&request.CustomerId,
+		&request.Status,
+		&request.Attempts,
+		&request.LastError,
+		&request.NextAttemptAt,
+		&request.LockedUntil,
+		&request.ExecutedAt,
+		&request.CancelledAt,
+		&request.RejectedAt,
+		&request.RejectionReason,
+		&request.ScheduledFor,
+		&request.CreatedAt,
+		&request.UpdatedAt)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	return request, nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// exhausted matches the interrupted executions that were the last attempt of
+// the request, the claim counts them before they are executed again.
+func exhausted(maxAttempts int) exp.Expression {
+	return goqu.And(
+		goqu.C("status").Eq(entity.DeletionRequestStatusExecuting),
+		goqu.L("attempts + 1").Gte(maxAttempts),
+	)
+}
+
+func reclaimedAttempts() exp.CaseExpression {
+	return goqu.Case().
+		When(goqu.C("status").Eq(entity.DeletionRequestStatusExecuting), goqu.L("attempts + 1")).
+		Else(goqu.C("attempts"))
+}
+
+func update(ctx context.Context, conn execer, request entity.DeletionRequest, claimedUntil time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status":           request.Status,
+			"attempts":         request.Attempts,
+			"last_error":       request.LastError,
+			"next_attempt_at":  request.NextAttemptAt,
+			"locked_until":     request.LockedUntil,
+			"executed_at":      request.ExecutedAt,
+			"cancelled_at":     request.CancelledAt,
+			"rejected_at":      request.RejectedAt,
+			"rejection_reason": request.RejectionReason,
+			"updated_at":       request.UpdatedAt,
+		}).
+		Where(goqu.Ex{
+			"id":           request.Id,
+			"status":       entity.DeletionRequestStatusExecuting,
+			"locked_until": claimedUntil,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrDeletionRequestLockLost
+	}
+
+	return nil
+}
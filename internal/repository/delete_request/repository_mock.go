@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -14,17 +16,93 @@ type MockRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, request
-func (_m *MockRepository) Create(ctx context.Context, request entity.DeletionRequest) error {
-	ret := _m.Called(ctx, request)
+// Cancel provides a mock function with given fields: ctx, id, cancelledAt
+func (_m *MockRepository) Cancel(ctx context.Context, id string, cancelledAt time.Time) error {
+	ret := _m.Called(ctx, id, cancelledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, cancelledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimById provides a mock function with given fields: ctx, id, now, lockedUntil
+func (_m *MockRepository) ClaimById(ctx context.Context, id string, now time.Time, lockedUntil time.Time) (entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, id, now, lockedUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimById")
+	}
+
+	var r0 entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (entity.DeletionRequest, error)); ok {
+		return rf(ctx, id, now, lockedUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) entity.DeletionRequest); ok {
+		r0 = rf(ctx, id, now, lockedUntil)
+	} else {
+		r0 = ret.Get(0).(entity.DeletionRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, now, lockedUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimPending provides a mock function with given fields: ctx, now, lockedUntil, limit, maxAttempts
+func (_m *MockRepository) ClaimPending(ctx context.Context, now time.Time, lockedUntil time.Time, limit int, maxAttempts int) ([]entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, now, lockedUntil, limit, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPending")
+	}
+
+	var r0 []entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int, int) ([]entity.DeletionRequest, error)); ok {
+		return rf(ctx, now, lockedUntil, limit, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int, int) []entity.DeletionRequest); ok {
+		r0 = rf(ctx, now, lockedUntil, limit, maxAttempts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DeletionRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int, int) error); ok {
+		r1 = rf(ctx, now, lockedUntil, limit, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, request, message
+func (_m *MockRepository) Create(ctx context.Context, request entity.DeletionRequest, message entity.OutboxMessage) error {
+	ret := _m.Called(ctx, request, message)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.DeletionRequest) error); ok {
-		r0 = rf(ctx, request)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DeletionRequest, entity.OutboxMessage) error); ok {
+		r0 = rf(ctx, request, message)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,6 +138,153 @@ func (_m *MockRepository) GetByCustomerId(ctx context.Context, customerId string
 	return r0, r1
 }
 
+// GetById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetById(ctx context.Context, id string) (entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.DeletionRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.DeletionRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.DeletionRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *MockRepository) List(ctx context.Context, filter Filter) ([]entity.DeletionRequest, int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.DeletionRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, Filter) ([]entity.DeletionRequest, int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Filter) []entity.DeletionRequest); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DeletionRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Filter) int); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkAsExecuted provides a mock function with given fields: ctx, request, claimedUntil, message
+func (_m *MockRepository) MarkAsExecuted(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time, message entity.OutboxMessage) error {
+	ret := _m.Called(ctx, request, claimedUntil, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAsExecuted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DeletionRequest, time.Time, entity.OutboxMessage) error); ok {
+		r0 = rf(ctx, request, claimedUntil, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reencrypt provides a mock function with given fields: ctx, afterId, limit
+func (_m *MockRepository) Reencrypt(ctx context.Context, afterId string, limit int) (ReencryptResult, error) {
+	ret := _m.Called(ctx, afterId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reencrypt")
+	}
+
+	var r0 ReencryptResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (ReencryptResult, error)); ok {
+		return rf(ctx, afterId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ReencryptResult); ok {
+		r0 = rf(ctx, afterId, limit)
+	} else {
+		r0 = ret.Get(0).(ReencryptResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: ctx, id, reason, rejectedAt
+func (_m *MockRepository) Reject(ctx context.Context, id string, reason string, rejectedAt time.Time) error {
+	ret := _m.Called(ctx, id, reason, rejectedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, reason, rejectedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, request, claimedUntil
+func (_m *MockRepository) Update(ctx context.Context, request entity.DeletionRequest, claimedUntil time.Time) error {
+	ret := _m.Called(ctx, request, claimedUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DeletionRequest, time.Time) error); ok {
+		r0 = rf(ctx, request, claimedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
@@ -3,6 +3,7 @@ package delete_request_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,10 +11,71 @@ import (
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/key_provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/encryption"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+var columns = []string{
+	"id",
+	"customer_id",
+	"name",
+	"address",
+	"phone",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"executed_at",
+	"cancelled_at",
+	"rejected_at",
+	"rejection_reason",
+	"scheduled_for",
+	"created_at",
+	"updated_at",
+	"data_key",
+	"key_version",
+}
+
+var claimedColumns = []string{
+	"id",
+	"customer_id",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"executed_at",
+	"cancelled_at",
+	"rejected_at",
+	"rejection_reason",
+	"scheduled_for",
+	"created_at",
+	"updated_at",
+}
+
+func newKeyProvider(t *testing.T, currentVersion int) provider.KeyProvider {
+	keyProvider, err := key_provider.NewLocalKeyProvider(map[int]string{
+		1: "MTExMTExMTExMTExMTExMTExMTExMTExMTExMTExMTE=",
+		2: "MjIyMjIyMjIyMjIyMjIyMjIyMjIyMjIyMjIyMjIyMjI=",
+	}, currentVersion)
+	assert.NoError(t, err)
+
+	return keyProvider
+}
+
+func seal(t *testing.T, keyProvider provider.KeyProvider, id string, values ...string) encryption.Sealed {
+	sealed, err := encryption.NewEnvelope(keyProvider).Seal(context.Background(), id, values...)
+	assert.NoError(t, err)
+
+	return sealed
+}
+
 func TestGetByCustomerId(t *testing.T) {
 	t.Run("Should return a deletion request", func(t *testing.T) {
 		// Arrange
@@ -24,8 +86,8 @@ func TestGetByCustomerId(t *testing.T) {
 		ctx := context.Background()
 
 		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "name", "address", "phone", "executed", "created_at", "updated_at"}).
-				AddRow("id", "customer_id", "name", "address", "phone", false, time.Now(), time.Now()))
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id", "customer_id", "name", "address", "phone", "pending", 0, "", time.Now(), time.Now(), nil, nil, nil, "", time.Now(), time.Now(), time.Now(), "", 0))
 
 		config := &environment.Config{
 			DbConfig: &environment.DatabaseConfig{
@@ -36,7 +98,7 @@ func TestGetByCustomerId(t *testing.T) {
 		service := database.NewDatabase(config)
 		service.(*database.Service).Client = db
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		res, err := repo.GetByCustomerId(ctx, "customer_id")
@@ -66,7 +128,7 @@ func TestGetByCustomerId(t *testing.T) {
 		service := database.NewDatabase(config)
 		service.(*database.Service).Client = db
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		res, err := repo.GetByCustomerId(ctx, "customer_id")
@@ -85,7 +147,7 @@ func TestGetByCustomerId(t *testing.T) {
 		ctx := context.Background()
 
 		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "name", "address", "phone", "executed", "created_at", "updated_at"}))
+			WillReturnRows(sqlmock.NewRows(columns))
 
 		config := &environment.Config{
 			DbConfig: &environment.DatabaseConfig{
@@ -96,7 +158,7 @@ func TestGetByCustomerId(t *testing.T) {
 		service := database.NewDatabase(config)
 		service.(*database.Service).Client = db
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		res, err := repo.GetByCustomerId(ctx, "customer_id")
@@ -115,8 +177,8 @@ func TestGetByCustomerId(t *testing.T) {
 		ctx := context.Background()
 
 		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "name", "address", "phone", "executed", "created_at", "updated_at"}).
-				AddRow("id", "customer_id", "name", "address", "phone", false, 123, time.Now()))
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id", "customer_id", "name", "address", "phone", "pending", 0, "", time.Now(), time.Now(), nil, nil, nil, "", time.Now(), 123, time.Now(), "", 0))
 
 		config := &environment.Config{
 			DbConfig: &environment.DatabaseConfig{
@@ -127,7 +189,7 @@ func TestGetByCustomerId(t *testing.T) {
 		service := database.NewDatabase(config)
 		service.(*database.Service).Client = db
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		res, err := repo.GetByCustomerId(ctx, "customer_id")
@@ -150,9 +212,11 @@ func TestCreate(t *testing.T) {
 		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO (.+)?customer_deletion_requests(.+)?").
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		err = repo.Create(ctx, entity.DeletionRequest{
@@ -161,13 +225,63 @@ func TestCreate(t *testing.T) {
 			Name:       "name",
 			Address:    "address",
 			Phone:      "phone",
-			Executed:   false,
+			Status:     entity.DeletionRequestStatusPending,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
+		}, entity.OutboxMessage{
+			Id:        "message_id",
+			EventType: entity.EventTypeCustomerDeletionRequested,
+			Status:    entity.OutboxMessageStatusPending,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should encrypt the contact data", func(t *testing.T) {
+		// Arrange
+		var inserted string
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+			if strings.Contains(actualSQL, "customer_deletion_requests") {
+				inserted = actualSQL
+			}
+
+			return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+		})))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		err = repo.Create(ctx, entity.DeletionRequest{
+			Id:         "id",
+			CustomerId: "customer_id",
+			Name:       "John Doe",
+			Address:    "Main Street, 42",
+			Phone:      "+5511999999999",
+			Status:     entity.DeletionRequestStatusPending,
+		}, entity.OutboxMessage{
+			Id: "message_id",
 		})
 
 		// Assert
 		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.NotEmpty(t, inserted)
+		assert.NotContains(t, inserted, "John Doe")
+		assert.NotContains(t, inserted, "Main Street, 42")
+		assert.NotContains(t, inserted, "+5511999999999")
 	})
 
 	t.Run("Should return an error when try to create a deletion request", func(t *testing.T) {
@@ -183,7 +297,7 @@ func TestCreate(t *testing.T) {
 			WillReturnError(errors.New("error"))
 		mock.ExpectRollback()
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		err = repo.Create(ctx, entity.DeletionRequest{
@@ -192,13 +306,77 @@ func TestCreate(t *testing.T) {
 			Name:       "name",
 			Address:    "address",
 			Phone:      "phone",
-			Executed:   false,
+			Status:     entity.DeletionRequestStatusPending,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
+		}, entity.OutboxMessage{
+			Id:        "message_id",
+			EventType: entity.EventTypeCustomerDeletionRequested,
+			Status:    entity.OutboxMessageStatusPending,
+		})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when the customer already has an active deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Create(ctx, entity.DeletionRequest{
+			Id:         "id",
+			CustomerId: "customer_id",
+			Status:     entity.DeletionRequestStatusPending,
+		}, entity.OutboxMessage{
+			Id: "message_id",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestAlreadyCreated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to add the message to the outbox", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Create(ctx, entity.DeletionRequest{
+			Id:         "id",
+			CustomerId: "customer_id",
+			Status:     entity.DeletionRequestStatusPending,
+		}, entity.OutboxMessage{
+			Id: "message_id",
 		})
 
 		// Assert
 		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("Should return an error when try to begin a transaction", func(t *testing.T) {
@@ -211,7 +389,7 @@ func TestCreate(t *testing.T) {
 
 		mock.ExpectBegin().WillReturnError(errors.New("error"))
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		err = repo.Create(ctx, entity.DeletionRequest{
@@ -220,9 +398,13 @@ func TestCreate(t *testing.T) {
 			Name:       "name",
 			Address:    "address",
 			Phone:      "phone",
-			Executed:   false,
+			Status:     entity.DeletionRequestStatusPending,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
+		}, entity.OutboxMessage{
+			Id:        "message_id",
+			EventType: entity.EventTypeCustomerDeletionRequested,
+			Status:    entity.OutboxMessageStatusPending,
 		})
 
 		// Assert
@@ -240,9 +422,11 @@ func TestCreate(t *testing.T) {
 		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO (.+)?customer_deletion_requests(.+)?").
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit().WillReturnError(errors.New("error"))
 
-		repo := delete_request.NewRepository(db)
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
 
 		// Act
 		err = repo.Create(ctx, entity.DeletionRequest{
@@ -251,12 +435,944 @@ func TestCreate(t *testing.T) {
 			Name:       "name",
 			Address:    "address",
 			Phone:      "phone",
-			Executed:   false,
+			Status:     entity.DeletionRequestStatusPending,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
+		}, entity.OutboxMessage{
+			Id:        "message_id",
+			EventType: entity.EventTypeCustomerDeletionRequested,
+			Status:    entity.OutboxMessageStatusPending,
 		})
 
 		// Assert
 		assert.Error(t, err)
 	})
 }
+
+func TestClaimPending(t *testing.T) {
+	t.Run("Should claim the pending deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery(`UPDATE (.+)?customer_deletion_requests(.+)? SET "attempts"=CASE +WHEN \("status" = 'executing'\) THEN attempts \+ 1 ELSE "attempts" END(.+) FOR UPDATE SKIP LOCKED(.+)? RETURNING "id", COALESCE\("customer_id", ''\) AS "customer_id", "status", (.+)?"updated_at"$`).
+			WillReturnRows(sqlmock.NewRows(claimedColumns).
+				AddRow("id-1", "customer_id-1", "pending", 0, "", now, now, nil, nil, nil, "", now, now, now).
+				AddRow("id-2", "customer_id-2", "executing", 1, "error", now, now, nil, nil, nil, "", now, now, now))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10, 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "id-1", res[0].Id)
+		assert.Equal(t, "id-2", res[1].Id)
+		assert.Equal(t, 1, res[1].Attempts)
+		assert.Equal(t, entity.DeletionRequestStatusExecuting, res[1].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should flag as failed the requests interrupted on their last attempt", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery(`UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?"last_error"=CASE +WHEN \(\("status" = 'executing'\) AND \(attempts \+ 1 >= 5\)\) THEN 'the execution was interrupted before it finished' ELSE "last_error" END(.+)?"status"=CASE +WHEN \(\("status" = 'executing'\) AND \(attempts \+ 1 >= 5\)\) THEN 'failed' ELSE 'executing' END(.+)?`).
+			WillReturnRows(sqlmock.NewRows(claimedColumns).
+				AddRow("id", "customer_id", "failed", 5, "the execution was interrupted before it finished", now, now, nil, nil, nil, "", now, now, now))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10, 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, entity.DeletionRequestStatusFailed, res[0].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an empty list when there is nothing to claim", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(claimedColumns))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10, 5)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return an error when try to claim the deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10, 5)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return an error when try to scan the results", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(claimedColumns).
+				AddRow("id", "customer_id", "pending", "abc", "", now, now, nil, nil, nil, "", now, now, now))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10, 5)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("Should update a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec(`UPDATE (.+)?customer_deletion_requests(.+)? WHERE (.+)?"id" = 'id'(.+)?"locked_until" = '2024-01-01T10:05:00Z'(.+)?"status" = 'executing'(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Update(ctx, entity.DeletionRequest{
+			Id:     "id",
+			Status: entity.DeletionRequestStatusPending,
+		}, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the deletion request was claimed by another execution", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Update(ctx, entity.DeletionRequest{
+			Id:     "id",
+			Status: entity.DeletionRequestStatusExecuted,
+		}, time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestLockLost)
+	})
+
+	t.Run("Should return an error when try to update a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Update(ctx, entity.DeletionRequest{
+			Id:     "id",
+			Status: entity.DeletionRequestStatusExecuted,
+		}, time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to get the affected rows", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Update(ctx, entity.DeletionRequest{
+			Id:     "id",
+			Status: entity.DeletionRequestStatusExecuted,
+		}, time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	t.Run("Should cancel a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?cancelled(.+)? WHERE (.+)?pending(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Cancel(ctx, "id", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the deletion request is no longer pending", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Cancel(ctx, "id", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeCancelled)
+	})
+
+	t.Run("Should return an error when try to cancel a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Cancel(ctx, "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to get the affected rows", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Cancel(ctx, "id", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestMarkAsExecuted(t *testing.T) {
+	t.Run("Should save the executed deletion request with the outbox message", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.MarkAsExecuted(ctx, entity.DeletionRequest{
+			Id:     "id",
+			Status: entity.DeletionRequestStatusExecuted,
+		}, time.Now(), entity.OutboxMessage{
+			Id: "message_id",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the deletion request was claimed by another execution", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.MarkAsExecuted(ctx, entity.DeletionRequest{
+			Id: "id",
+		}, time.Now(), entity.OutboxMessage{
+			Id: "message_id",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestLockLost)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to add the message to the outbox", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.MarkAsExecuted(ctx, entity.DeletionRequest{
+			Id: "id",
+		}, time.Now(), entity.OutboxMessage{
+			Id: "message_id",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to begin a transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin().WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.MarkAsExecuted(ctx, entity.DeletionRequest{
+			Id: "id",
+		}, time.Now(), entity.OutboxMessage{
+			Id: "message_id",
+		})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("Should return a page of deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM (.+)?customer_deletion_requests(.+)? WHERE (.+)?status(.+)?customer_id(.+)?created_at(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)? WHERE (.+) ORDER BY (.+) LIMIT (.+) OFFSET (.+)").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id-1", "customer_id", "name", "address", "phone", "pending", 0, "", now, now, nil, nil, nil, "", now, now, now, "", 0).
+				AddRow("id-2", "customer_id", "name", "address", "phone", "pending", 0, "", now, now, nil, nil, nil, "", now, now, now, "", 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, total, err := repo.List(ctx, delete_request.Filter{
+			Status:     entity.DeletionRequestStatusPending,
+			CustomerId: "customer_id",
+			From:       now.Add(-time.Hour),
+			To:         now,
+			Limit:      2,
+			Offset:     2,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "id-1", res[0].Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should not query the page when there is nothing to return", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, total, err := repo.List(ctx, delete_request.Filter{Limit: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to count the deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, total, err := repo.List(ctx, delete_request.Filter{Limit: 10})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, total)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return an error when try to list the deletion requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, total, err := repo.List(ctx, delete_request.Filter{Limit: 10})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, total)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return an error when try to scan the results", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id", "customer_id", "name", "address", "phone", "pending", "abc", "", now, now, nil, nil, nil, "", now, now, now, "", 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, total, err := repo.List(ctx, delete_request.Filter{Limit: 10})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, total)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	t.Run("Should return a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)? WHERE (.+)?id(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id", "customer_id", "name", "address", "phone", "rejected", 0, "", now, now, nil, nil, now, "duplicated", now, now, now, "", 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.GetById(ctx, "id")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "id", res.Id)
+		assert.Equal(t, entity.DeletionRequestStatusRejected, res.Status)
+		assert.Equal(t, "duplicated", res.RejectionReason)
+		assert.NotNil(t, res.RejectedAt)
+	})
+
+	t.Run("Should return an error when deletion request is not found", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.GetById(ctx, "id")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestNotFound)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return an error when try to get a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.GetById(ctx, "id")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestClaimById(t *testing.T) {
+	t.Run("Should claim the deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery(`UPDATE (.+)?customer_deletion_requests(.+)? SET "attempts"=CASE +WHEN \("status" = 'executing'\) THEN attempts \+ 1 (.+) WHERE (.+)?id(.+)?pending(.+)?failed(.+)? RETURNING (.+)`).
+			WillReturnRows(sqlmock.NewRows(claimedColumns).
+				AddRow("id", "customer_id", "executing", 0, "", now, now, nil, nil, nil, "", now, now, now))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimById(ctx, "id", now, now.Add(time.Minute))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "id", res.Id)
+		assert.Equal(t, entity.DeletionRequestStatusExecuting, res.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the deletion request cannot be claimed", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(claimedColumns))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimById(ctx, "id", now, now.Add(time.Minute))
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeExecuted)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return an error when try to claim the deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		res, err := repo.ClaimById(ctx, "id", now, now.Add(time.Minute))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestReject(t *testing.T) {
+	t.Run("Should reject a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?rejected(.+)? WHERE (.+)?pending(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Reject(ctx, "id", "duplicated", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the deletion request is no longer pending", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Reject(ctx, "id", "duplicated", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeRejected)
+	})
+
+	t.Run("Should return an error when try to reject a deletion request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 1))
+
+		// Act
+		err = repo.Reject(ctx, "id", "duplicated", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestReencrypt(t *testing.T) {
+	sealedColumns := []string{"id", "name", "address", "phone", "data_key", "key_version"}
+
+	t.Run("Should re-encrypt the requests with the current key version", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		sealed := seal(t, newKeyProvider(t, 1), "id-2", "Jane Doe", "Main Street, 43", "+5511888888888")
+
+		mock.ExpectQuery(`SELECT (.+) FROM (.+)?customer_deletion_requests(.+)? WHERE (.+)?"key_version" < 2(.+)?"id" > 'id-0'(.+)? ORDER BY (.+)?id(.+)?`).
+			WillReturnRows(sqlmock.NewRows(sealedColumns).
+				AddRow("id-1", "John Doe", "Main Street, 42", "+5511999999999", "", 0).
+				AddRow("id-2", sealed.Values[0], sealed.Values[1], sealed.Values[2], sealed.DataKey, sealed.KeyVersion))
+		mock.ExpectExec(`UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?"key_version"=2(.+)? WHERE (.+)?"address" = 'Main Street, 42'(.+)?"data_key" = ''(.+)?id-1(.+)?"key_version" = 0(.+)?"name" = 'John Doe'(.+)?"phone" = '\+5511999999999'(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE (.+)?customer_deletion_requests(.+)? SET (.+)?"key_version"=2(.+)? WHERE (.+)?id-2(.+)?"key_version" = 1(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "id-0", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{LastId: "id-2", Read: 2, Reencrypted: 2}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should not overwrite the requests scrubbed in the meantime", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery(`SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?`).
+			WillReturnRows(sqlmock.NewRows(sealedColumns).
+				AddRow("id-1", "John Doe", "Main Street, 42", "+5511999999999", "", 0))
+		mock.ExpectExec(`UPDATE (.+)?customer_deletion_requests(.+)? WHERE (.+)?"name" = 'John Doe'(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{LastId: "id-1", Read: 1, Reencrypted: 0}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should skip the requests that cannot be decrypted", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(sealedColumns).
+				AddRow("id-1", "name", "address", "phone", "ZGF0YS1rZXk=", 1).
+				AddRow("id-2", "John Doe", "Main Street, 42", "+5511999999999", "", 0))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)? WHERE (.+)?id-2(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{LastId: "id-2", Read: 2, Reencrypted: 1, Skipped: 1}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should not count the requests changed while re-encrypted", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(sealedColumns).
+				AddRow("id", "name", "address", "phone", "", 0))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{LastId: "id", Read: 1}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an empty result when there is nothing to re-encrypt", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(sealedColumns))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to list the outdated requests", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, delete_request.ReencryptResult{}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to update the request", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_deletion_requests(.+)?").
+			WillReturnRows(sqlmock.NewRows(sealedColumns).
+				AddRow("id", "name", "address", "phone", "", 0))
+		mock.ExpectExec("UPDATE (.+)?customer_deletion_requests(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := delete_request.NewRepository(db, newKeyProvider(t, 2))
+
+		// Act
+		res, err := repo.Reencrypt(ctx, "", 10)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, res.Reencrypted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,14 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+)
+
+type Repository interface {
+	ClaimPending(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]entity.OutboxMessage, error)
+	Update(ctx context.Context, message entity.OutboxMessage, claimedUntil time.Time) error
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+const (
+	tableName = "outbox_messages"
+)
+
+var columns = []interface{}{
+	"id",
+	"event_type",
+	"event_version",
+	"correlation_id",
+	"payload",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"sent_at",
+	"created_at",
+	"updated_at",
+}
+
+type repository struct {
+	conn *sql.DB
+}
+
+func NewRepository(conn *sql.DB) Repository {
+	return &repository{
+		conn: conn,
+	}
+}
+
+// Insert adds the message to the outbox using the transaction of the change
+// that raised it, so both are committed, or discarded, together.
+func Insert(ctx context.Context, tx *sql.Tx, message entity.OutboxMessage) error {
+	sql, params, err := goqu.Insert(tableName).
+		Cols(columns...).
+		Vals(goqu.Vals{
+			message.Id,
+			message.EventType,
+			message.EventVersion,
+			message.CorrelationId,
+			message.Payload,
+			message.Status,
+			message.Attempts,
+			message.LastError,
+			message.NextAttemptAt,
+			message.LockedUntil,
+			message.SentAt,
+			message.CreatedAt,
+			message.UpdatedAt,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = database.ExecContext(ctx, tx, tableName, sql, params...)
+
+	return err
+}
+
+func (r *repository) ClaimPending(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]entity.OutboxMessage, error) {
+	pending := goqu.
+		From(tableName).
+		Select("id").
+		Where(
+			goqu.C("status").Eq(entity.OutboxMessageStatusPending),
+			goqu.C("next_attempt_at").Lte(now),
+			goqu.C("locked_until").Lte(now),
+		).
+		Order(goqu.C("created_at").Asc()).
+		Limit(uint(limit)).
+		ForUpdate(exp.SkipLocked)
+
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"locked_until": lockedUntil,
+			"updated_at":   now,
+		}).
+		Where(goqu.C("id").In(pending)).
+		Returning(columns...).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer statement.Close()
+
+	messages := make([]entity.OutboxMessage, 0, limit)
+
+	for statement.Next() {
+		message, err := scan(statement)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, statement.Err()
+}
+
+// Update saves the outcome of a publication, as long as the message is still
+// claimed with the lock returned by the claim. Otherwise the lock expired and
+// another relay claimed the message in the meantime.
+func (r *repository) Update(ctx context.Context, message entity.OutboxMessage, claimedUntil time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"status":          message.Status,
+			"attempts":        message.Attempts,
+			"last_error":      message.LastError,
+			"next_attempt_at": message.NextAttemptAt,
+			"locked_until":    message.LockedUntil,
+			"sent_at":         message.SentAt,
+			"updated_at":      message.UpdatedAt,
+		}).
+		Where(goqu.Ex{
+			"id":           message.Id,
+			"status":       entity.OutboxMessageStatusPending,
+			"locked_until": claimedUntil,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrOutboxMessageLockLost
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	sql, params, err := goqu.
+		Delete(tableName).
+		Where(
+			goqu.C("status").Eq(entity.OutboxMessageStatusSent),
+			goqu.C("sent_at").Lt(before),
+		).
+		ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := database.ExecContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func scan(rows *sql.Rows) (entity.OutboxMessage, error) {
+	message := entity.OutboxMessage{}
+
+	err := rows.Scan(
+		&message.Id,
+		&message.EventType,
+		&message.EventVersion,
+		&message.CorrelationId,
+		&message.Payload,
+		&message.Status,
+		&message.Attempts,
+		&message.LastError,
+		&message.NextAttemptAt,
+		&message.LockedUntil,
+		&message.SentAt,
+		&message.CreatedAt,
+		&message.UpdatedAt)
+
+	return message, err
+}
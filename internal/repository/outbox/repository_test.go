@@ -0,0 +1,297 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var columns = []string{
+	"id",
+	"event_type",
+	"event_version",
+	"correlation_id",
+	"payload",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"locked_until",
+	"sent_at",
+	"created_at",
+	"updated_at",
+}
+
+func TestInsert(t *testing.T) {
+	t.Run("Should add a message to the outbox", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		// Act
+		err = outbox.Insert(ctx, tx, entity.OutboxMessage{
+			Id:        "id",
+			EventType: entity.EventTypeCustomerDeleted,
+			Payload:   "{}",
+			Status:    entity.OutboxMessageStatusPending,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to add a message to the outbox", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?outbox_messages(.+)?").
+			WillReturnError(errors.New("error"))
+
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		// Act
+		err = outbox.Insert(ctx, tx, entity.OutboxMessage{
+			Id: "id",
+		})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestClaimPending(t *testing.T) {
+	t.Run("Should claim the pending messages", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?outbox_messages(.+)? SET (.+) FOR UPDATE SKIP LOCKED(.+)? RETURNING (.+)").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id-1", "CustomerDeleted", 1, "correlation-1", "{}", "pending", 0, "", now, now, nil, now, now).
+				AddRow("id-2", "CustomerDeletionRequested", 1, "correlation-2", "{}", "pending", 2, "error", now, now, nil, now, now))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "id-1", res[0].Id)
+		assert.Equal(t, entity.EventTypeCustomerDeleted, res[0].EventType)
+		assert.Equal(t, 2, res[1].Attempts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to claim the messages", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?outbox_messages(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return an error when try to scan the results", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("UPDATE (.+)?outbox_messages(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("id-1", "CustomerDeleted", "abc", "correlation-1", "{}", "pending", 0, "", now, now, nil, now, now))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		res, err := repo.ClaimPending(ctx, now, now.Add(time.Minute), 10)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("Should update a message", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec(`UPDATE (.+)?outbox_messages(.+)? WHERE (.+)?"id" = 'id'(.+)?"locked_until" = '2024-01-01T10:05:00Z'(.+)?"status" = 'pending'(.+)?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		err = repo.Update(ctx, entity.OutboxMessage{
+			Id:     "id",
+			Status: entity.OutboxMessageStatusSent,
+		}, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the message was claimed by another relay", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		err = repo.Update(ctx, entity.OutboxMessage{
+			Id: "id",
+		}, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOutboxMessageLockLost)
+	})
+
+	t.Run("Should return an error when try to update a message", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?outbox_messages(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		err = repo.Update(ctx, entity.OutboxMessage{
+			Id: "id",
+		}, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to get the affected rows", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?outbox_messages(.+)?").
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		err = repo.Update(ctx, entity.OutboxMessage{
+			Id: "id",
+		}, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteSentBefore(t *testing.T) {
+	t.Run("Should delete the old sent messages", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("DELETE FROM (.+)?outbox_messages(.+)?sent(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		res, err := repo.DeleteSentBefore(ctx, time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to delete the messages", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("DELETE FROM (.+)?outbox_messages(.+)?").
+			WillReturnError(sql.ErrConnDone)
+
+		repo := outbox.NewRepository(db)
+
+		// Act
+		res, err := repo.DeleteSentBefore(ctx, time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
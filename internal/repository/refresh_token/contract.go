@@ -0,0 +1,16 @@
+package refresh_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+)
+
+type Repository interface {
+	GetByHash(ctx context.Context, tokenHash string) (entity.RefreshToken, error)
+	Create(ctx context.Context, token entity.RefreshToken) error
+	Rotate(ctx context.Context, id string, next entity.RefreshToken, now time.Time) error
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+	RevokeAllByCustomerId(ctx context.Context, customerId string, revokedAt time.Time) error
+}
@@ -0,0 +1,191 @@
+package refresh_token
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+const (
+	tableName = "customer_refresh_tokens"
+)
+
+var columns = []interface{}{
+	"id",
+	"customer_id",
+	"token_hash",
+	"expires_at",
+	"revoked_at",
+	"created_at",
+	"updated_at",
+}
+
+type repository struct {
+	conn *sql.DB
+}
+
+func NewRepository(conn *sql.DB) Repository {
+	return &repository{
+		conn: conn,
+	}
+}
+
+func (r *repository) GetByHash(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	sql, params, err := goqu.
+		From(tableName).
+		Select(columns...).
+		Where(goqu.Ex{
+			"token_hash": tokenHash,
+		}).
+		ToSQL()
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	statement, err := database.QueryContext(ctx, r.conn, tableName, sql, params...)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	defer statement.Close()
+
+	if !statement.Next() {
+		if err := statement.Err(); err != nil {
+			return entity.RefreshToken{}, err
+		}
+
+		return entity.RefreshToken{}, custom_error.ErrRefreshTokenNotFound
+	}
+
+	return scan(statement)
+}
+
+func (r *repository) Create(ctx context.Context, token entity.RefreshToken) error {
+	return create(ctx, r.conn, token)
+}
+
+// Rotate revokes the token and creates the one replacing it, the token is
+// only revoked if it was not revoked yet, so the same token can not be
+// exchanged twice by concurrent requests.
+func (r *repository) Rotate(ctx context.Context, id string, next entity.RefreshToken, now time.Time) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := revoke(ctx, tx, id, now); err != nil {
+		return err
+	}
+
+	if err := create(ctx, tx, next); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	return revoke(ctx, r.conn, id, revokedAt)
+}
+
+func (r *repository) RevokeAllByCustomerId(ctx context.Context, customerId string, revokedAt time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).
+		Where(
+			goqu.C("customer_id").Eq(customerId),
+			goqu.C("revoked_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = database.ExecContext(ctx, r.conn, tableName, sql, params...)
+
+	return err
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func create(ctx context.Context, conn execer, token entity.RefreshToken) error {
+	sql, params, err := goqu.
+		Insert(tableName).
+		Rows(goqu.Record{
+			"id":          token.Id,
+			"customer_id": token.CustomerId,
+			"token_hash":  token.TokenHash,
+			"expires_at":  token.ExpiresAt,
+			"revoked_at":  token.RevokedAt,
+			"created_at":  token.CreatedAt,
+			"updated_at":  token.UpdatedAt,
+		}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = database.ExecContext(ctx, conn, tableName, sql, params...)
+
+	return err
+}
+
+func revoke(ctx context.Context, conn execer, id string, revokedAt time.Time) error {
+	sql, params, err := goqu.
+		Update(tableName).
+		Set(goqu.Record{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).
+		Where(
+			goqu.C("id").Eq(id),
+			goqu.C("revoked_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.ExecContext(ctx, conn, tableName, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return custom_error.ErrRefreshTokenNotFound
+	}
+
+	return nil
+}
+
+func scan(rows *sql.Rows) (entity.RefreshToken, error) {
+	token := entity.RefreshToken{}
+
+	err := rows.Scan(
+		&token.Id,
+		&token.CustomerId,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+		&token.UpdatedAt)
+
+	return token, err
+}
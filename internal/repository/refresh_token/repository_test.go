@@ -0,0 +1,284 @@
+package refresh_token_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/refresh_token"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var columns = []string{"id", "customer_id", "token_hash", "expires_at", "revoked_at", "created_at", "updated_at"}
+
+func TestRepository_GetByHash(t *testing.T) {
+	t.Run("Should return the refresh token", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_refresh_tokens(.+)? WHERE (.+)?token_hash(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("token-1", "customer-1", "hash", now.Add(time.Hour), nil, now, now))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		res, err := repo.GetByHash(context.Background(), "hash")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", res.Id)
+		assert.Equal(t, "customer-1", res.CustomerId)
+		assert.False(t, res.IsRevoked())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the refresh token is not found", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_refresh_tokens(.+)?").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		res, err := repo.GetByHash(context.Background(), "hash")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRefreshTokenNotFound)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		_, err = repo.GetByHash(context.Background(), "hash")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Run("Should create a refresh token", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Create(context.Background(), entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Create(context.Background(), entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_Rotate(t *testing.T) {
+	t.Run("Should revoke the token and create the next one", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)? SET (.+)?revoked_at(.+)? WHERE (.+)?revoked_at(.+)? IS NULL(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Rotate(context.Background(), "token-1", entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour), time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the token was already revoked", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Rotate(context.Background(), "token-1", entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour), time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRefreshTokenNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when try to begin a transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin().
+			WillReturnError(errors.New("error"))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Rotate(context.Background(), "token-1", entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour), time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error when try to create the next token", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Rotate(context.Background(), "token-1", entity.NewRefreshToken("customer-1", "hash", time.Now(), time.Hour), time.Now())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_Revoke(t *testing.T) {
+	t.Run("Should revoke the token", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Revoke(context.Background(), "token-1", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error when the token is not active", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.Revoke(context.Background(), "token-1", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRefreshTokenNotFound)
+	})
+}
+
+func TestRepository_RevokeAllByCustomerId(t *testing.T) {
+	t.Run("Should revoke all the tokens of the customer", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)? WHERE (.+)?customer_id(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.RevokeAllByCustomerId(context.Background(), "customer-1", time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return an error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE (.+)?customer_refresh_tokens(.+)?").
+			WillReturnError(errors.New("error"))
+
+		repo := refresh_token.NewRepository(db)
+
+		// Act
+		err = repo.RevokeAllByCustomerId(context.Background(), "customer-1", time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
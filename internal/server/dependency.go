@@ -1,15 +1,46 @@
 package server
 
 import (
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/password_provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/token_provider"
 
 	customer_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	delete_request_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	outbox_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
+	refresh_token_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/refresh_token"
+	admin_deletion_request_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	auth_session_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	customer_credentials_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
 	customer_delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	customer_execute_deletion_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/execute_deletion"
+	customer_profile_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	customer_reencryption_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/reencryption"
+	customer_registration_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	outbox_relay_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/outbox/relay"
 )
 
 type Dependency struct {
-	TimeProvider *time_provider.TimeProvider
+	TimeProvider     *time_provider.TimeProvider
+	PasswordProvider *password_provider.PasswordProvider
+	TokenProvider    *token_provider.TokenProvider
+	KeyProvider      provider.KeyProvider
 
-	CustomerRepository customer_repository.Repository
-	CustomerService    customer_delete_account_svc.Service
+	CustomerRepository      customer_repository.Repository
+	DeleteRequestRepository delete_request_repository.Repository
+	OutboxRepository        outbox_repository.Repository
+	RefreshTokenRepository  refresh_token_repository.Repository
+
+	CustomerService        customer_delete_account_svc.Service
+	CustomerProfileService customer_profile_svc.Service
+	RegistrationService    customer_registration_svc.Service
+	CredentialsService     customer_credentials_svc.Service
+	ExecuteDeletionService customer_execute_deletion_svc.Service
+	ReencryptionService    customer_reencryption_svc.Service
+	OutboxRelayService     outbox_relay_svc.Service
+
+	AdminDeletionRequestService admin_deletion_request_svc.Service
+
+	SessionService auth_session_svc.Service
 }
@@ -1,45 +1,150 @@
 package token
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/jwks"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
 	"github.com/labstack/echo/v4"
 )
 
-func Middleware() echo.MiddlewareFunc {
+const (
+	bearerPrefix = "bearer "
+
+	errorCodeInvalidRequest = "invalid_request"
+	errorCodeInvalidToken   = "invalid_token"
+)
+
+var (
+	errTokenRequired       = errors.New("the authorization header is required")
+	errNotBearerToken      = errors.New("the authorization header must have a bearer token")
+	errSubjectRequired     = errors.New("the token must have a subject")
+	errSecretNotConfigured = errors.New("hmac signed tokens are not accepted, no secret configured")
+	errJwksNotConfigured   = errors.New("asymmetric signed tokens are not accepted, no jwks configured")
+)
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+// Middleware verifies the signature of the token, HS256 tokens are verified
+// with the shared secret and RS256/ES256 tokens with the keys of the JWKS.
+// The authenticated principal is put in the context of the request.
+func Middleware(config *environment.AuthConfig, keySet jwks.KeySet) echo.MiddlewareFunc {
+	parser := jwt.NewParser(parserOptions(config, keySet)...)
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			tokenHeader := c.Request().Header.Get("Authorization")
+			tokenHeader := c.Request().Header.Get(echo.HeaderAuthorization)
 			if tokenHeader == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Token is required")
+				return unauthorized(c, "", errTokenRequired)
+			}
+
+			if len(tokenHeader) <= len(bearerPrefix) || !strings.EqualFold(tokenHeader[:len(bearerPrefix)], bearerPrefix) {
+				return unauthorized(c, errorCodeInvalidRequest, errNotBearerToken)
 			}
 
-			tokenValue := tokenHeader[7:]
-			token, _, err := new(jwt.Parser).ParseUnverified(tokenValue, jwt.MapClaims{})
-			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+			tokenValue := strings.TrimSpace(tokenHeader[len(bearerPrefix):])
+			if tokenValue == "" {
+				return unauthorized(c, errorCodeInvalidRequest, errNotBearerToken)
 			}
 
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+			ctx := c.Request().Context()
+
+			tokenClaims := &claims{}
+			if _, err := parser.ParseWithClaims(tokenValue, tokenClaims, keyFunc(ctx, config, keySet)); err != nil {
+				return unauthorized(c, errorCodeInvalidToken, err)
 			}
 
-			expiration := claims["exp"].(float64)
-			if expiration < float64(time.Now().Unix()) {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+			if tokenClaims.Subject == "" {
+				return unauthorized(c, errorCodeInvalidToken, errSubjectRequired)
 			}
 
-			userId, ok := claims["sub"].(string)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+			principal := auth.Principal{
+				Subject: tokenClaims.Subject,
+				Roles:   tokenClaims.Roles,
+				TokenId: tokenClaims.ID,
 			}
 
-			c.Set("userId", userId)
+			ctx = logger.With(ctx, "customer_id", principal.Subject)
+
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(ctx, principal)))
 
 			return next(c)
 		}
 	}
 }
+
+// unauthorized follows RFC 6750, the error code is only sent when the
+// client tried to authenticate.
+func unauthorized(c echo.Context, errorCode string, err error) error {
+	challenge := `Bearer realm="api"`
+	message := "token is required"
+
+	if errorCode != "" {
+		challenge = fmt.Sprintf(`%s, error="%s"`, challenge, errorCode)
+		message = "invalid token"
+	}
+
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+
+	return custom_error.NewHttpAppError(http.StatusUnauthorized, message, err)
+}
+
+func parserOptions(config *environment.AuthConfig, keySet jwks.KeySet) []jwt.ParserOption {
+	methods := make([]string, 0, 3)
+
+	if config.IsSecretSet() {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if keySet != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return options
+}
+
+func keyFunc(ctx context.Context, config *environment.AuthConfig, keySet jwks.KeySet) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if !config.IsSecretSet() {
+				return nil, errSecretNotConfigured
+			}
+
+			return []byte(config.Secret), nil
+		default:
+			if keySet == nil {
+				return nil, errJwksNotConfigured
+			}
+
+			kid, _ := token.Header["kid"].(string)
+
+			return keySet.GetKey(ctx, kid)
+		}
+	}
+}
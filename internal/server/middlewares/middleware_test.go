@@ -1,7 +1,14 @@
 package token_test
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,11 +16,21 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/jwks"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
 	token "github.com/jfelipearaujo-org/ms-customer-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+var config = &environment.AuthConfig{
+	Secret: "my-secret",
+}
+
 func generateToken(t *testing.T, userId string, expire time.Duration) string {
 	claims := jwt.MapClaims{
 		"sub": userId,
@@ -26,28 +43,75 @@ func generateToken(t *testing.T, userId string, expire time.Duration) string {
 		}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
 
-	tokenString, err := token.SignedString([]byte("my-secret"))
+	tokenString, err := token.SignedString(key)
 	assert.NoError(t, err)
 
 	return fmt.Sprintf("Bearer %s", tokenString)
 }
 
+func serve(config *environment.AuthConfig, keySet jwks.KeySet, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(echo.GET, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	res := httptest.NewRecorder()
+
+	e := echo.New()
+	e.Use(token.Middleware(config, keySet))
+	e.GET("/", func(c echo.Context) error {
+		principal, err := auth.FromContext(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.String(http.StatusOK, principal.Subject)
+	})
+
+	e.ServeHTTP(res, req)
+
+	return res
+}
+
 func TestMiddleware(t *testing.T) {
 	t.Run("Should authorize when token is valid", func(t *testing.T) {
 		// Arrange
 		userId := uuid.NewString()
 
+		// Act
+		res := serve(config, nil, generateToken(t, userId, time.Minute*1))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, userId, res.Body.String())
+	})
+
+	t.Run("Should add the customer id to the logger of the request", func(t *testing.T) {
+		// Arrange
+		userId := uuid.NewString()
+
+		buffer := &bytes.Buffer{}
+
 		req := httptest.NewRequest(echo.GET, "/", nil)
+		req = req.WithContext(logger.WithLogger(req.Context(), slog.New(slog.NewJSONHandler(buffer, nil))))
 		req.Header.Set("Authorization", generateToken(t, userId, time.Minute*1))
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(config, nil))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			ctx := c.Request().Context()
+			logger.FromContext(ctx).InfoContext(ctx, "message")
+
+			return c.NoContent(http.StatusOK)
 		})
 
 		// Act
@@ -55,87 +119,356 @@ func TestMiddleware(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusOK, res.Code)
-		assert.Equal(t, userId, res.Body.String())
+		assert.Contains(t, buffer.String(), fmt.Sprintf(`"customer_id":"%s"`, userId))
 	})
 
 	t.Run("Should not authorize when token is invalid", func(t *testing.T) {
 		// Arrange
-		req := httptest.NewRequest(echo.GET, "/", nil)
-		req.Header.Set("Authorization", "invalid-token")
-		res := httptest.NewRecorder()
+		// Act
+		res := serve(config, nil, "invalid-token")
 
-		e := echo.New()
-		e.Use(token.Middleware())
-		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when token is missing", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := serve(config, nil, "")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when token is expired", func(t *testing.T) {
+		// Arrange
+		userId := uuid.NewString()
+
+		// Act
+		res := serve(config, nil, generateToken(t, userId, time.Second*-1))
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when token doest not have user id", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := serve(config, nil, generateToken(t, "no-claims", time.Minute*1))
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when token is signed with another secret", func(t *testing.T) {
+		// Arrange
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("another-secret"), "", jwt.MapClaims{
+			"sub": uuid.NewString(),
+			"exp": time.Now().Add(time.Minute).Unix(),
 		})
 
 		// Act
-		e.ServeHTTP(res, req)
+		res := serve(config, nil, tokenValue)
 
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
 	})
 
-	t.Run("Should not authorize when token is missing", func(t *testing.T) {
+	t.Run("Should not authorize when token is not signed", func(t *testing.T) {
 		// Arrange
-		req := httptest.NewRequest(echo.GET, "/", nil)
-		res := httptest.NewRecorder()
+		tokenValue := signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", jwt.MapClaims{
+			"sub": uuid.NewString(),
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
 
-		e := echo.New()
-		e.Use(token.Middleware())
-		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+		// Act
+		res := serve(config, nil, tokenValue)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when token does not expire", func(t *testing.T) {
+		// Arrange
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": uuid.NewString(),
 		})
 
 		// Act
-		e.ServeHTTP(res, req)
+		res := serve(config, nil, tokenValue)
 
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
 	})
 
-	t.Run("Should not authorize when token is expired", func(t *testing.T) {
+	t.Run("Should not authorize when token is not valid yet", func(t *testing.T) {
 		// Arrange
-		userId := uuid.NewString()
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": uuid.NewString(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"nbf": time.Now().Add(time.Minute).Unix(),
+		})
 
-		req := httptest.NewRequest(echo.GET, "/", nil)
-		req.Header.Set("Authorization", generateToken(t, userId, time.Second*-1))
-		res := httptest.NewRecorder()
+		// Act
+		res := serve(config, nil, tokenValue)
 
-		e := echo.New()
-		e.Use(token.Middleware())
-		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should validate the issuer and the audience", func(t *testing.T) {
+		// Arrange
+		config := &environment.AuthConfig{
+			Secret:   "my-secret",
+			Issuer:   "https://auth.example.com",
+			Audience: "customers",
+		}
+
+		validToken := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"iss": "https://auth.example.com",
+			"aud": "customers",
+		})
+
+		wrongIssuerToken := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"iss": "https://another.example.com",
+			"aud": "customers",
+		})
+
+		wrongAudienceToken := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"iss": "https://auth.example.com",
+			"aud": "orders",
 		})
 
 		// Act
-		e.ServeHTTP(res, req)
+		validRes := serve(config, nil, validToken)
+		wrongIssuerRes := serve(config, nil, wrongIssuerToken)
+		wrongAudienceRes := serve(config, nil, wrongAudienceToken)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, validRes.Code)
+		assert.Equal(t, http.StatusUnauthorized, wrongIssuerRes.Code)
+		assert.Equal(t, http.StatusUnauthorized, wrongAudienceRes.Code)
+	})
+
+	t.Run("Should authorize when token is signed with a RSA key of the key set", func(t *testing.T) {
+		// Arrange
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+
+		keySet := jwks.NewMockKeySet(t)
+		keySet.On("GetKey", mock.Anything, "rsa-key").
+			Return(&privateKey.PublicKey, nil)
+
+		tokenValue := signToken(t, jwt.SigningMethodRS256, privateKey, "rsa-key", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+
+		// Act
+		res := serve(&environment.AuthConfig{}, keySet, tokenValue)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "user-id", res.Body.String())
+		keySet.AssertExpectations(t)
+	})
+
+	t.Run("Should authorize when token is signed with a EC key of the key set", func(t *testing.T) {
+		// Arrange
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+
+		keySet := jwks.NewMockKeySet(t)
+		keySet.On("GetKey", mock.Anything, "ec-key").
+			Return(&privateKey.PublicKey, nil)
+
+		tokenValue := signToken(t, jwt.SigningMethodES256, privateKey, "ec-key", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+
+		// Act
+		res := serve(&environment.AuthConfig{}, keySet, tokenValue)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		keySet.AssertExpectations(t)
+	})
+
+	t.Run("Should not authorize when the key is not in the key set", func(t *testing.T) {
+		// Arrange
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+
+		keySet := jwks.NewMockKeySet(t)
+		keySet.On("GetKey", mock.Anything, "unknown-key").
+			Return(nil, jwks.ErrKeyNotFound)
+
+		tokenValue := signToken(t, jwt.SigningMethodRS256, privateKey, "unknown-key", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+
+		// Act
+		res := serve(&environment.AuthConfig{}, keySet, tokenValue)
 
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		keySet.AssertExpectations(t)
 	})
 
-	t.Run("Should not authorize when token doest not have user id", func(t *testing.T) {
+	t.Run("Should not authorize a HS256 token when there is no secret", func(t *testing.T) {
+		// Arrange
+		keySet := jwks.NewMockKeySet(t)
+
+		// Act
+		res := serve(&environment.AuthConfig{}, keySet, generateToken(t, "user-id", time.Minute))
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		keySet.AssertExpectations(t)
+	})
+
+	t.Run("Should not authorize a RS256 token when there is no key set", func(t *testing.T) {
+		// Arrange
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+
+		tokenValue := signToken(t, jwt.SigningMethodRS256, privateKey, "rsa-key", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+
+		// Act
+		res := serve(config, nil, tokenValue)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should put the principal in the context of the request", func(t *testing.T) {
 		// Arrange
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub":   "user-id",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"jti":   "token-id",
+			"roles": []string{"admin", "customer"},
+		})
+
 		req := httptest.NewRequest(echo.GET, "/", nil)
-		req.Header.Set("Authorization", generateToken(t, "no-claims", time.Minute*1))
+		req.Header.Set("Authorization", tokenValue)
 		res := httptest.NewRecorder()
 
+		var principal auth.Principal
+
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(config, nil))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			var err error
+			principal, err = auth.FromContext(c.Request().Context())
+			if err != nil {
+				return err
+			}
+
+			return c.NoContent(http.StatusOK)
 		})
 
 		// Act
 		e.ServeHTTP(res, req)
 
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, auth.Principal{
+			Subject: "user-id",
+			Roles:   []string{"admin", "customer"},
+			TokenId: "token-id",
+		}, principal)
+	})
+
+	t.Run("Should accept the bearer scheme in any case", func(t *testing.T) {
+		// Arrange
+		tokenValue := generateToken(t, "user-id", time.Minute)
+
+		// Act
+		res := serve(config, nil, "bEaReR "+tokenValue[len("Bearer "):])
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Should not authorize when the authorization header is malformed", func(t *testing.T) {
+		headers := []string{
+			"Bearer",
+			"Bearer ",
+			"Bearer    ",
+			"Basic dXNlcjpwYXNzd29yZA==",
+			"abc",
+			generateToken(t, "user-id", time.Minute)[len("Bearer "):],
+		}
+
+		for _, header := range headers {
+			// Arrange
+			// Act
+			res := serve(config, nil, header)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, res.Code, header)
+			assert.Equal(t, `Bearer realm="api", error="invalid_request"`, res.Header().Get(echo.HeaderWWWAuthenticate), header)
+		}
+	})
+
+	t.Run("Should not authorize when the roles claim is malformed", func(t *testing.T) {
+		// Arrange
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub":   "user-id",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"roles": "admin",
+		})
+
+		// Act
+		res := serve(config, nil, tokenValue)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Should not authorize when the expiration claim is malformed", func(t *testing.T) {
+		// Arrange
+		tokenValue := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-id",
+			"exp": "tomorrow",
+		})
+
+		// Act
+		res := serve(config, nil, tokenValue)
+
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
 	})
+
+	t.Run("Should return an app error with the authentication challenge", func(t *testing.T) {
+		// Arrange
+		// Act
+		missingRes := serve(config, nil, "")
+		invalidRes := serve(config, nil, generateToken(t, "user-id", -time.Minute))
+
+		// Assert
+		var missingBody custom_error.AppError
+		assert.NoError(t, json.Unmarshal(missingRes.Body.Bytes(), &missingBody))
+		assert.Equal(t, http.StatusUnauthorized, missingBody.Code)
+		assert.Equal(t, "token is required", missingBody.Message)
+		assert.Equal(t, `Bearer realm="api"`, missingRes.Header().Get(echo.HeaderWWWAuthenticate))
+
+		var invalidBody custom_error.AppError
+		assert.NoError(t, json.Unmarshal(invalidRes.Body.Bytes(), &invalidBody))
+		assert.Equal(t, http.StatusUnauthorized, invalidBody.Code)
+		assert.Equal(t, "invalid token", invalidBody.Message)
+		assert.Equal(t, `Bearer realm="api", error="invalid_token"`, invalidRes.Header().Get(echo.HeaderWWWAuthenticate))
+	})
 }
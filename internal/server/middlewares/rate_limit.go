@@ -0,0 +1,39 @@
+package token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+var errRateLimitExceeded = errors.New("too many requests, try again later")
+
+// RateLimit limits the requests of each principal, so a valid token cannot be
+// used to walk through the documents. It must run after the token middleware.
+func RateLimit(limit float64, burst int) echo.MiddlewareFunc {
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  rate.Limit(limit),
+			Burst: burst,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			principal, err := auth.FromContext(c.Request().Context())
+			if err != nil {
+				return "", err
+			}
+
+			return principal.Subject, nil
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return unauthorized(c, "", err)
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return custom_error.NewHttpAppError(http.StatusTooManyRequests, "too many requests", errRateLimitExceeded)
+		},
+	})
+}
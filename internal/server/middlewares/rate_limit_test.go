@@ -0,0 +1,78 @@
+package token_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	token "github.com/jfelipearaujo-org/ms-customer-management/internal/server/middlewares"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("Should allow the requests within the burst", func(t *testing.T) {
+		// Arrange
+		authorization := generateToken(t, uuid.NewString(), time.Hour)
+
+		middlewares := []echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RateLimit(1, 2),
+		}
+
+		// Act
+		first := serveWithRole(middlewares, authorization)
+		second := serveWithRole(middlewares, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, first.Code)
+		assert.Equal(t, http.StatusNoContent, second.Code)
+	})
+
+	t.Run("Should return too many requests when the principal exceeds the limit", func(t *testing.T) {
+		// Arrange
+		authorization := generateToken(t, uuid.NewString(), time.Hour)
+
+		middlewares := []echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RateLimit(0.001, 1),
+		}
+
+		serveWithRole(middlewares, authorization)
+
+		// Act
+		res := serveWithRole(middlewares, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, res.Code)
+	})
+
+	t.Run("Should limit each principal on its own", func(t *testing.T) {
+		// Arrange
+		middlewares := []echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RateLimit(0.001, 1),
+		}
+
+		serveWithRole(middlewares, generateToken(t, uuid.NewString(), time.Hour))
+
+		// Act
+		res := serveWithRole(middlewares, generateToken(t, uuid.NewString(), time.Hour))
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.RateLimit(1, 1),
+		}, "")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+}
@@ -0,0 +1,33 @@
+package token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/auth"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+var errMissingRole = errors.New("the token does not have the required role")
+
+// RequireRole allows the request when the principal has any of the roles,
+// it must run after the token middleware.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := auth.FromContext(c.Request().Context())
+			if err != nil {
+				return unauthorized(c, "", err)
+			}
+
+			for _, role := range roles {
+				if principal.HasRole(role) {
+					return next(c)
+				}
+			}
+
+			return custom_error.NewHttpAppError(http.StatusForbidden, "forbidden", errMissingRole)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package token_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	token "github.com/jfelipearaujo-org/ms-customer-management/internal/server/middlewares"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithRole(middlewares []echo.MiddlewareFunc, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(echo.GET, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	res := httptest.NewRecorder()
+
+	e := echo.New()
+	e.Use(middlewares...)
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	e.ServeHTTP(res, req)
+
+	return res
+}
+
+func TestRequireRole(t *testing.T) {
+	t.Run("Should allow the request when the principal has the role", func(t *testing.T) {
+		// Arrange
+		authorization := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub":   uuid.NewString(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"support", "admin"},
+		})
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RequireRole("admin"),
+		}, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Should allow the request when the principal has any of the roles", func(t *testing.T) {
+		// Arrange
+		authorization := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub":   uuid.NewString(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"support"},
+		})
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RequireRole("admin", "support"),
+		}, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Should return forbidden when the principal does not have the role", func(t *testing.T) {
+		// Arrange
+		authorization := signToken(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub":   uuid.NewString(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"customer"},
+		})
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RequireRole("admin"),
+		}, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Contains(t, res.Body.String(), "forbidden")
+	})
+
+	t.Run("Should return forbidden when the token has no roles", func(t *testing.T) {
+		// Arrange
+		authorization := generateToken(t, uuid.NewString(), time.Hour)
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.Middleware(config, nil),
+			token.RequireRole("admin"),
+		}, authorization)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("Should return unauthorized when there is no authenticated principal", func(t *testing.T) {
+		// Arrange
+
+		// Act
+		res := serveWithRole([]echo.MiddlewareFunc{
+			token.RequireRole("admin"),
+		}, "")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.Equal(t, `Bearer realm="api"`, res.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+}
@@ -8,24 +8,66 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/jwks"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/execute_deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/get_deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/list_deletion_requests"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/admin/reject_deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/anonymous_login"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/login"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/logout"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/auth/refresh_token"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/cancel_delete_account"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account_message"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/delete_account_status"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/get_profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/identify_customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/register_customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/customer/update_profile"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/handler/health"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/key_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/password_provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/token_provider"
 	token "github.com/jfelipearaujo-org/ms-customer-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	shared_health "github.com/jfelipearaujo-org/ms-customer-management/internal/shared/health"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/metrics"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/tracing"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/worker"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 
 	customer_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
 	delete_request_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	outbox_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
+	refresh_token_repository "github.com/jfelipearaujo-org/ms-customer-management/internal/repository/refresh_token"
+	admin_deletion_request_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	auth_session_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	customer_credentials_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
 	customer_delete_account_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
+	customer_execute_deletion_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/execute_deletion"
+	customer_profile_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	customer_reencryption_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/reencryption"
+	customer_registration_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	outbox_relay_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/outbox/relay"
 )
 
 type Server struct {
 	Config          *environment.Config
 	DatabaseService database.DatabaseService
+	KeySet          jwks.KeySet
+	HealthRegistry  *shared_health.Registry
+
+	DeletionQueueService cloud.QueueService
+	DeletionTopicService cloud.TopicService
 
 	Dependency Dependency
 }
@@ -42,21 +84,128 @@ func NewServer(config *environment.Config) *Server {
 		cloudConfig.BaseEndpoint = aws.String(config.CloudConfig.BaseEndpoint)
 	}
 
+	otelaws.AppendMiddlewares(&cloudConfig.APIOptions)
+
 	databaseService := database.NewDatabase(config)
 
+	if err := metrics.RegisterDatabase("postgres", databaseService.GetInstance()); err != nil {
+		panic(err)
+	}
+
 	timeProvider := time_provider.NewTimeProvider(time.Now)
+	passwordProvider := password_provider.NewPasswordProvider(config.PasswordConfig.HashCost)
+	tokenProvider := token_provider.NewTokenProvider(config.AuthConfig)
+
+	keyProvider, err := newKeyProvider(config.EncryptionConfig, cloudConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	var keySet jwks.KeySet
+	if config.AuthConfig.IsJwksSet() {
+		keySet = jwks.NewKeySet(config.AuthConfig.JwksUrl,
+			config.AuthConfig.JwksRefreshInterval,
+			&http.Client{Timeout: 10 * time.Second},
+			timeProvider)
+	}
 
 	customer_repository := customer_repository.NewRepository(databaseService.GetInstance())
-	delete_request_repository := delete_request_repository.NewRepository(databaseService.GetInstance())
+	delete_request_repository := delete_request_repository.NewRepository(databaseService.GetInstance(), keyProvider)
+	outbox_repository := outbox_repository.NewRepository(databaseService.GetInstance())
+	refresh_token_repository := refresh_token_repository.NewRepository(databaseService.GetInstance())
+
+	deletionTopicService := cloud.NewNoopTopicService()
+	if config.CloudConfig.IsDeletionTopicSet() {
+		deletionTopicService = cloud.NewTopicService(config.CloudConfig.DeletionTopicName, cloudConfig)
+	}
+
+	customerService := customer_delete_account_svc.NewService(config.DeletionConfig,
+		timeProvider,
+		customer_repository,
+		delete_request_repository)
+
+	registrationService := customer_registration_svc.NewService(timeProvider, passwordProvider, customer_repository)
+
+	credentialsService := customer_credentials_svc.NewService(config.PasswordConfig,
+		timeProvider,
+		passwordProvider,
+		customer_repository)
+
+	profileService := customer_profile_svc.NewService(timeProvider,
+		passwordProvider,
+		credentialsService,
+		customer_repository)
+
+	executeDeletionService := customer_execute_deletion_svc.NewService(config.DeletionConfig,
+		timeProvider,
+		customer_repository,
+		delete_request_repository)
+
+	var deletionQueueService cloud.QueueService
+	if config.CloudConfig.IsDeletionQueueSet() {
+		deletionQueueService = cloud.NewQueueService(config.CloudConfig.DeletionQueueName,
+			cloudConfig,
+			config.CloudConfig,
+			delete_account_message.NewHandler(customerService))
+	}
+
+	// only the database is needed to serve the requests, the other
+	// components degrade the service without taking it out of the balancer
+	healthRegistry := shared_health.NewRegistry(config.ApiConfig.HealthCheckTimeout, timeProvider)
+	healthRegistry.Register("database", databaseService)
+	healthRegistry.RegisterOptional("secrets_manager", cloud.NewSecretHealthCheck(config.DbConfig.UrlSecretName, cloudConfig))
+
+	if deletionQueueService != nil {
+		healthRegistry.RegisterOptional("deletion_queue", deletionQueueService)
+	}
+
+	if config.CloudConfig.IsDeletionTopicSet() {
+		healthRegistry.RegisterOptional("deletion_topic", deletionTopicService)
+	}
 
 	return &Server{
 		Config:          config,
 		DatabaseService: databaseService,
+		KeySet:          keySet,
+		HealthRegistry:  healthRegistry,
+
+		DeletionQueueService: deletionQueueService,
+		DeletionTopicService: deletionTopicService,
+
 		Dependency: Dependency{
-			TimeProvider: timeProvider,
+			TimeProvider:     timeProvider,
+			PasswordProvider: passwordProvider,
+			TokenProvider:    tokenProvider,
+			KeyProvider:      keyProvider,
+
+			CustomerRepository:      customer_repository,
+			DeleteRequestRepository: delete_request_repository,
+			OutboxRepository:        outbox_repository,
+			RefreshTokenRepository:  refresh_token_repository,
+
+			CustomerService:        customerService,
+			CustomerProfileService: profileService,
+			RegistrationService:    registrationService,
+			CredentialsService:     credentialsService,
+			ExecuteDeletionService: executeDeletionService,
+			ReencryptionService: customer_reencryption_svc.NewService(config.EncryptionConfig,
+				delete_request_repository),
+			OutboxRelayService: outbox_relay_svc.NewService(config.OutboxConfig,
+				timeProvider,
+				outbox_repository,
+				deletionTopicService),
+
+			AdminDeletionRequestService: admin_deletion_request_svc.NewService(timeProvider,
+				delete_request_repository,
+				executeDeletionService),
 
-			CustomerRepository: customer_repository,
-			CustomerService:    customer_delete_account_svc.NewService(customer_repository, delete_request_repository),
+			SessionService: auth_session_svc.NewService(config.AuthConfig,
+				timeProvider,
+				tokenProvider,
+				credentialsService,
+				registrationService,
+				customer_repository,
+				refresh_token_repository),
 		},
 	}
 }
@@ -71,29 +220,164 @@ func (s *Server) GetHttpServer() *http.Server {
 	}
 }
 
+// GetMetricsServer serves the metrics on a port of its own, which is only
+// reachable inside the cluster, out of the routes of the ingress.
+func (s *Server) GetMetricsServer() *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.Config.ApiConfig.MetricsPort),
+		Handler:      s.RegisterMetricsRoutes(),
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+}
+
+func (s *Server) GetDeletionWorker() *worker.Worker {
+	return worker.NewWorker("deletion-executor",
+		s.Config.DeletionConfig.PollInterval,
+		s.Dependency.ExecuteDeletionService.ExecutePending)
+}
+
+func (s *Server) GetReencryptionWorker() *worker.Worker {
+	return worker.NewWorker("deletion-request-reencryption",
+		s.Config.EncryptionConfig.ReencryptInterval,
+		s.Dependency.ReencryptionService.Reencrypt)
+}
+
+func (s *Server) GetOutboxRelayWorker() *worker.Worker {
+	return worker.NewWorker("outbox-relay",
+		s.Config.OutboxConfig.PollInterval,
+		s.Dependency.OutboxRelayService.Relay)
+}
+
+func (s *Server) GetOutboxCleanupWorker() *worker.Worker {
+	return worker.NewWorker("outbox-cleanup",
+		s.Config.OutboxConfig.CleanupInterval,
+		s.Dependency.OutboxRelayService.Cleanup)
+}
+
 func (s *Server) RegisterRoutes() http.Handler {
 	e := echo.New()
+	e.HTTPErrorHandler = errorHandler(e, s.Config.ApiConfig.IsDevelopment())
+
+	e.Use(tracing.Middleware(s.Config.TracingConfig.ServiceName))
 	e.Use(logger.Middleware())
+	e.Use(metrics.Middleware())
 	e.Use(middleware.Recover())
 
 	s.registerHealthCheck(e)
 
 	group := e.Group(fmt.Sprintf("/api/%s", s.Config.ApiConfig.ApiVersion))
 
-	s.registerCustomerHandlers(group)
+	s.registerPublicCustomerHandlers(group)
+
+	authenticated := group.Group("", token.Middleware(s.Config.AuthConfig, s.KeySet))
+
+	if s.Config.AuthConfig.IsIssuingEnabled() {
+		s.registerAuthHandlers(group.Group("/auth"), authenticated.Group("/auth"))
+	}
+
+	s.registerIdentifyCustomerHandler(authenticated)
+	s.registerCustomerHandlers(authenticated)
+	s.registerAdminHandlers(authenticated.Group("/admin"))
 
 	return e
 }
 
+func (s *Server) RegisterMetricsRoutes() http.Handler {
+	e := echo.New()
+
+	e.GET("/metrics", metrics.Handler())
+
+	return e
+}
+
+// newKeyProvider uses the same version:key list for both providers, the keys
+// are KMS key ids for the KMS provider and base64 master keys otherwise.
+func newKeyProvider(config *environment.EncryptionConfig, cloudConfig aws.Config) (provider.KeyProvider, error) {
+	keys, err := key_provider.ParseKeys(config.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.IsKmsEnabled() {
+		return cloud.NewKmsKeyProvider(cloudConfig, keys, config.CurrentKeyVersion)
+	}
+
+	return key_provider.NewLocalKeyProvider(keys, config.CurrentKeyVersion)
+}
+
+// errorHandler only sends the details of the internal errors to the clients
+// in development, elsewhere they are only logged.
+func errorHandler(e *echo.Echo, showInternalDetails bool) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if !showInternalDetails {
+			err = custom_error.HideInternalDetails(err)
+		}
+
+		e.DefaultHTTPErrorHandler(err, c)
+	}
+}
+
 func (server *Server) registerHealthCheck(e *echo.Echo) {
-	healthHandler := health.NewHandler(server.DatabaseService)
+	healthHandler := health.NewHandler(server.HealthRegistry, server.Config.ApiConfig.IsDevelopment())
+
+	e.GET("/health", healthHandler.Ready)
+	e.GET("/health/live", healthHandler.Live)
+	e.GET("/health/ready", healthHandler.Ready)
+}
+
+func (s *Server) registerPublicCustomerHandlers(e *echo.Group) {
+	registerCustomerHandler := register_customer.NewHandler(s.Dependency.RegistrationService)
 
-	e.GET("/health", healthHandler.Handle)
+	e.POST("/customers", registerCustomerHandler.Handle)
+}
+
+// registerIdentifyCustomerHandler keeps the lookup by document to the kiosks
+// and the admins, rate limited, as it tells whether a document is registered.
+func (s *Server) registerIdentifyCustomerHandler(e *echo.Group) {
+	identifyCustomerHandler := identify_customer.NewHandler(s.Dependency.RegistrationService)
+
+	e.POST("/customers/identify", identifyCustomerHandler.Handle,
+		token.RequireRole(s.Config.AuthConfig.KioskRole, s.Config.AuthConfig.AdminRole),
+		token.RateLimit(s.Config.ApiConfig.IdentifyRateLimit, s.Config.ApiConfig.IdentifyRateBurst))
+}
+
+func (s *Server) registerAuthHandlers(public *echo.Group, authenticated *echo.Group) {
+	loginHandler := login.NewHandler(s.Dependency.SessionService)
+	anonymousLoginHandler := anonymous_login.NewHandler(s.Dependency.SessionService)
+	refreshTokenHandler := refresh_token.NewHandler(s.Dependency.SessionService)
+	logoutHandler := logout.NewHandler(s.Dependency.SessionService)
+
+	public.POST("/login", loginHandler.Handle)
+	public.POST("/anonymous", anonymousLoginHandler.Handle)
+	public.POST("/refresh", refreshTokenHandler.Handle)
+	authenticated.POST("/logout", logoutHandler.Handle)
 }
 
 func (s *Server) registerCustomerHandlers(e *echo.Group) {
 	customerHandler := delete_account.NewHandler(s.Dependency.CustomerService)
+	deleteAccountStatusHandler := delete_account_status.NewHandler(s.Dependency.CustomerService)
+	cancelDeleteAccountHandler := cancel_delete_account.NewHandler(s.Dependency.CustomerService)
+	getProfileHandler := get_profile.NewHandler(s.Dependency.CustomerProfileService)
+	updateProfileHandler := update_profile.NewHandler(s.Dependency.CustomerProfileService)
 
-	e.Use(token.Middleware())
+	e.GET("/customers/me", getProfileHandler.Handle)
+	e.PATCH("/customers/me", updateProfileHandler.Handle)
 	e.POST("/customers/delete-account", customerHandler.Handle)
+	e.GET("/customers/delete-account", deleteAccountStatusHandler.Handle)
+	e.DELETE("/customers/delete-account", cancelDeleteAccountHandler.Handle)
+}
+
+func (s *Server) registerAdminHandlers(e *echo.Group) {
+	listDeletionRequestsHandler := list_deletion_requests.NewHandler(s.Dependency.AdminDeletionRequestService)
+	getDeletionRequestHandler := get_deletion_request.NewHandler(s.Dependency.AdminDeletionRequestService)
+	executeDeletionRequestHandler := execute_deletion_request.NewHandler(s.Dependency.AdminDeletionRequestService)
+	rejectDeletionRequestHandler := reject_deletion_request.NewHandler(s.Dependency.AdminDeletionRequestService)
+
+	e.Use(token.RequireRole(s.Config.AuthConfig.AdminRole))
+	e.GET("/deletion-requests", listDeletionRequestsHandler.Handle)
+	e.GET("/deletion-requests/:id", getDeletionRequestHandler.Handle)
+	e.POST("/deletion-requests/:id/execute", executeDeletionRequestHandler.Handle)
+	e.POST("/deletion-requests/:id/reject", rejectDeletionRequestHandler.Handle)
 }
@@ -1,12 +1,30 @@
 package server
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/token_provider"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	admin_deletion_request_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	auth_session_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	customer_profile_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	customer_registration_svc "github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
 )
 
+// encryptionKeys holds a single local master key of the version 1
+const encryptionKeys = "1:MTExMTExMTExMTExMTExMTExMTExMTExMTExMTExMTE="
+
 func TestNewServer(t *testing.T) {
 	t.Run("Should return a new server", func(t *testing.T) {
 		// Arrange
@@ -17,7 +35,16 @@ func TestNewServer(t *testing.T) {
 			DbConfig: &environment.DatabaseConfig{
 				Url: "postgres://host:1234",
 			},
-			CloudConfig: &environment.CloudConfig{},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			TracingConfig:  &environment.TracingConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
 		}
 
 		// Act
@@ -39,6 +66,15 @@ func TestNewServer(t *testing.T) {
 			CloudConfig: &environment.CloudConfig{
 				BaseEndpoint: "http://localhost:5000",
 			},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			TracingConfig:  &environment.TracingConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
 		}
 
 		// Act
@@ -48,7 +84,40 @@ func TestNewServer(t *testing.T) {
 		assert.NotNil(t, server)
 	})
 
-	t.Run("Should create a http server", func(t *testing.T) {
+	t.Run("Should return a new server with the deletion queue and topic", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				DeletionQueueName: "deletion-queue",
+				DeletionTopicName: "deletion-topic",
+			},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			TracingConfig:  &environment.TracingConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
+		}
+
+		// Act
+		server := NewServer(config)
+
+		// Assert
+		assert.NotNil(t, server)
+		assert.Equal(t, "deletion-queue", server.DeletionQueueService.GetQueueName())
+		assert.Equal(t, "deletion-topic", server.DeletionTopicService.GetTopicName())
+	})
+
+	t.Run("Should return a new server with the jwks key set", func(t *testing.T) {
 		// Arrange
 		config := &environment.Config{
 			ApiConfig: &environment.ApiConfig{
@@ -58,6 +127,44 @@ func TestNewServer(t *testing.T) {
 				Url: "postgres://host:1234",
 			},
 			CloudConfig: &environment.CloudConfig{},
+			AuthConfig: &environment.AuthConfig{
+				JwksUrl: "http://localhost:8080/.well-known/jwks.json",
+			},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
+		}
+
+		// Act
+		server := NewServer(config)
+
+		// Assert
+		assert.NotNil(t, server)
+		assert.NotNil(t, server.KeySet)
+	})
+
+	t.Run("Should create a http server", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			TracingConfig:  &environment.TracingConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
 		}
 
 		server := NewServer(config)
@@ -69,4 +176,514 @@ func TestNewServer(t *testing.T) {
 		assert.NotNil(t, httpServer)
 		assert.Equal(t, ":5000", httpServer.Addr)
 	})
+
+	t.Run("Should create the metrics server on its own port", func(t *testing.T) {
+		// Arrange
+		server := &Server{
+			Config: &environment.Config{
+				ApiConfig: &environment.ApiConfig{
+					MetricsPort: 9090,
+				},
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		metricsServer := server.GetMetricsServer()
+		metricsServer.Handler.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, ":9090", metricsServer.Addr)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), "customer_management_deletion_requests_created_total")
+	})
+
+	t.Run("Should create a deletion worker", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{
+				PollInterval: time.Minute,
+			},
+			OutboxConfig: &environment.OutboxConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
+		}
+
+		server := NewServer(config)
+
+		// Act
+		worker := server.GetDeletionWorker()
+
+		// Assert
+		assert.NotNil(t, worker)
+	})
+
+	t.Run("Should create the outbox workers", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig: &environment.OutboxConfig{
+				PollInterval:    5 * time.Second,
+				CleanupInterval: time.Hour,
+			},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+			},
+		}
+
+		server := NewServer(config)
+
+		// Act
+		relayWorker := server.GetOutboxRelayWorker()
+		cleanupWorker := server.GetOutboxCleanupWorker()
+
+		// Assert
+		assert.NotNil(t, relayWorker)
+		assert.NotNil(t, cleanupWorker)
+	})
+
+	t.Run("Should create the reencryption worker", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 1,
+				ReencryptInterval: time.Hour,
+			},
+		}
+
+		server := NewServer(config)
+
+		// Act
+		worker := server.GetReencryptionWorker()
+
+		// Assert
+		assert.NotNil(t, worker)
+	})
+
+	t.Run("Should panic if the current key version has no key", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 5000,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig:    &environment.CloudConfig{},
+			AuthConfig:     &environment.AuthConfig{},
+			PasswordConfig: &environment.PasswordConfig{},
+			DeletionConfig: &environment.DeletionConfig{},
+			OutboxConfig:   &environment.OutboxConfig{},
+			EncryptionConfig: &environment.EncryptionConfig{
+				Keys:              encryptionKeys,
+				CurrentKeyVersion: 2,
+			},
+		}
+
+		// Act
+		// Assert
+		assert.Panics(t, func() {
+			NewServer(config)
+		})
+	})
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	newToken := func(t *testing.T, roles ...string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":   "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": roles,
+		}).SignedString([]byte("my-secret"))
+		assert.NoError(t, err)
+
+		return fmt.Sprintf("Bearer %s", token)
+	}
+
+	newServer := func(dependency Dependency) http.Handler {
+		server := &Server{
+			Config: &environment.Config{
+				ApiConfig: &environment.ApiConfig{
+					ApiVersion:        "v1",
+					IdentifyRateLimit: 1,
+					IdentifyRateBurst: 5,
+				},
+				AuthConfig: &environment.AuthConfig{
+					Secret:    "my-secret",
+					AdminRole: "admin",
+					KioskRole: "kiosk",
+				},
+				TracingConfig: &environment.TracingConfig{},
+			},
+			Dependency: dependency,
+		}
+
+		return server.RegisterRoutes()
+	}
+
+	t.Run("Should allow the admin routes for the admin role", func(t *testing.T) {
+		// Arrange
+		service := admin_deletion_request_svc.NewMockService(t)
+
+		service.On("Get", mock.Anything, admin_deletion_request_svc.DeletionRequestRequest{
+			Id: "4fc3a1a2-9a43-4d43-a5e1-4f6a1f1a7a11",
+		}).
+			Return(entity.DeletionRequest{Id: "4fc3a1a2-9a43-4d43-a5e1-4f6a1f1a7a11"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deletion-requests/4fc3a1a2-9a43-4d43-a5e1-4f6a1f1a7a11", nil)
+		req.Header.Set("Authorization", newToken(t, "admin"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{AdminDeletionRequestService: service}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return forbidden for the admin routes without the admin role", func(t *testing.T) {
+		// Arrange
+		service := admin_deletion_request_svc.NewMockService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deletion-requests", nil)
+		req.Header.Set("Authorization", newToken(t, "customer"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{AdminDeletionRequestService: service}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized for the admin routes without a token", func(t *testing.T) {
+		// Arrange
+		service := admin_deletion_request_svc.NewMockService(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deletion-requests", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{AdminDeletionRequestService: service}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should allow the registration without a token", func(t *testing.T) {
+		// Arrange
+		registrationService := customer_registration_svc.NewMockService(t)
+
+		registrationService.On("Register", mock.Anything, customer_registration_svc.RegisterCustomerRequest{
+			IsAnonymous: true,
+		}).
+			Return(customer_registration_svc.CustomerResponse{Id: "customer-1", IsAnonymous: true}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/customers", strings.NewReader(`{"is_anonymous":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{RegistrationService: registrationService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		registrationService.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the customer routes protected", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/me", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("Should allow the customer identification for the kiosk role", func(t *testing.T) {
+		// Arrange
+		registrationService := customer_registration_svc.NewMockService(t)
+
+		registrationService.On("Identify", mock.Anything, customer_registration_svc.IdentifyCustomerRequest{
+			DocumentId: "52998224725",
+		}).
+			Return(customer_registration_svc.CustomerResponse{Id: "customer-1"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/customers/identify", strings.NewReader(`{"document_id":"52998224725"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", newToken(t, "kiosk"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{RegistrationService: registrationService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		registrationService.AssertExpectations(t)
+	})
+
+	t.Run("Should return unauthorized for the customer identification without a token", func(t *testing.T) {
+		// Arrange
+		registrationService := customer_registration_svc.NewMockService(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/customers/identify", strings.NewReader(`{"document_id":"52998224725"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{RegistrationService: registrationService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		registrationService.AssertExpectations(t)
+	})
+
+	t.Run("Should return forbidden for the customer identification without the kiosk role", func(t *testing.T) {
+		// Arrange
+		registrationService := customer_registration_svc.NewMockService(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/customers/identify", strings.NewReader(`{"document_id":"52998224725"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", newToken(t, "customer"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{RegistrationService: registrationService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		registrationService.AssertExpectations(t)
+	})
+
+	t.Run("Should allow the login without a token", func(t *testing.T) {
+		// Arrange
+		sessionService := auth_session_svc.NewMockService(t)
+
+		sessionService.On("Login", mock.Anything, auth_session_svc.LoginRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		}).
+			Return(auth_session_svc.TokenResponse{AccessToken: "access-token"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"document_id":"52998224725","password":"12345678"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{SessionService: sessionService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		sessionService.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the logout protected", func(t *testing.T) {
+		// Arrange
+		sessionService := auth_session_svc.NewMockService(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", strings.NewReader(`{"all":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{SessionService: sessionService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		sessionService.AssertExpectations(t)
+	})
+
+	t.Run("Should accept the access tokens issued by the service", func(t *testing.T) {
+		// Arrange
+		profileService := customer_profile_svc.NewMockService(t)
+
+		profileService.On("Get", mock.Anything, "customer-1").
+			Return(customer_profile_svc.ProfileResponse{Id: "customer-1"}, nil)
+
+		tokenProvider := token_provider.NewTokenProvider(&environment.AuthConfig{
+			Secret:         "my-secret",
+			AccessTokenTtl: time.Minute,
+		})
+
+		accessToken, err := tokenProvider.IssueAccessToken("customer-1", time.Now())
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/me", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{CustomerProfileService: profileService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		profileService.AssertExpectations(t)
+	})
+
+	t.Run("Should route the profile update with the If-Match header", func(t *testing.T) {
+		// Arrange
+		profileService := customer_profile_svc.NewMockService(t)
+
+		profileService.On("Update", mock.Anything, "customer-1", customer_profile_svc.UpdateProfileRequest{
+			Version:         1,
+			CurrentPassword: "12345678",
+			Password:        "87654321",
+		}).
+			Return(customer_profile_svc.ProfileResponse{Id: "customer-1", Version: 2}, nil)
+
+		tokenProvider := token_provider.NewTokenProvider(&environment.AuthConfig{
+			Secret:         "my-secret",
+			AccessTokenTtl: time.Minute,
+		})
+
+		accessToken, err := tokenProvider.IssueAccessToken("customer-1", time.Now())
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/customers/me", strings.NewReader(`{"current_password":"12345678","password":"87654321"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		req.Header.Set("If-Match", `"1"`)
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{CustomerProfileService: profileService}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+		profileService.AssertExpectations(t)
+	})
+
+	t.Run("Should not register the auth routes when there is no secret to sign the tokens", func(t *testing.T) {
+		// Arrange
+		server := &Server{
+			Config: &environment.Config{
+				ApiConfig: &environment.ApiConfig{
+					ApiVersion: "v1",
+				},
+				AuthConfig: &environment.AuthConfig{
+					JwksUrl: "http://localhost:8080/.well-known/jwks.json",
+				},
+				TracingConfig: &environment.TracingConfig{},
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/anonymous", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		server.RegisterRoutes().ServeHTTP(resp, req)
+
+		// Assert
+		// unknown routes fall under the authenticated group
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("Should not expose the metrics on the api routes", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("Should hide the details of the internal errors outside development", func(t *testing.T) {
+		// Arrange
+		service := admin_deletion_request_svc.NewMockService(t)
+
+		service.On("Get", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, errors.New("pq: value John Doe"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deletion-requests/4fc3a1a2-9a43-4d43-a5e1-4f6a1f1a7a11", nil)
+		req.Header.Set("Authorization", newToken(t, "admin"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		newServer(Dependency{AdminDeletionRequestService: service}).ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.NotContains(t, resp.Body.String(), "John Doe")
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should show the details of the internal errors in development", func(t *testing.T) {
+		// Arrange
+		service := admin_deletion_request_svc.NewMockService(t)
+
+		service.On("Get", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, errors.New("pq: value John Doe"))
+
+		server := &Server{
+			Config: &environment.Config{
+				ApiConfig: &environment.ApiConfig{
+					EnvName:    "development",
+					ApiVersion: "v1",
+				},
+				AuthConfig: &environment.AuthConfig{
+					Secret:    "my-secret",
+					AdminRole: "admin",
+				},
+				TracingConfig: &environment.TracingConfig{},
+			},
+			Dependency: Dependency{AdminDeletionRequestService: service},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deletion-requests/4fc3a1a2-9a43-4d43-a5e1-4f6a1f1a7a11", nil)
+		req.Header.Set("Authorization", newToken(t, "admin"))
+		resp := httptest.NewRecorder()
+
+		// Act
+		server.RegisterRoutes().ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Contains(t, resp.Body.String(), "John Doe")
+		service.AssertExpectations(t)
+	})
 }
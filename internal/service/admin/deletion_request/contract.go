@@ -0,0 +1,85 @@
+package deletion_request
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
+)
+
+const (
+	defaultPage     = 1
+	defaultPageSize = 20
+)
+
+type ListDeletionRequestsRequest struct {
+	Status     string    `query:"status" validate:"omitempty,oneof=pending cancelled executing executed failed rejected"`
+	CustomerId string    `query:"customer_id" validate:"omitempty,uuid4"`
+	From       time.Time `query:"from"`
+	To         time.Time `query:"to"`
+	Page       int       `query:"page" validate:"omitempty,min=1"`
+	PageSize   int       `query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+func (r *ListDeletionRequestsRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return custom_error.ErrRequestNotValid
+	}
+
+	if r.Page == 0 {
+		r.Page = defaultPage
+	}
+
+	if r.PageSize == 0 {
+		r.PageSize = defaultPageSize
+	}
+
+	return nil
+}
+
+type ListDeletionRequestsResponse struct {
+	Items      []entity.DeletionRequest `json:"items"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalItems int                      `json:"total_items"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+type DeletionRequestRequest struct {
+	Id string `param:"id" validate:"required,uuid4"`
+}
+
+func (r *DeletionRequestRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type RejectDeletionRequestRequest struct {
+	Id string `param:"id" json:"-" validate:"required,uuid4"`
+
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func (r *RejectDeletionRequestRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type Service interface {
+	List(ctx context.Context, request ListDeletionRequestsRequest) (ListDeletionRequestsResponse, error)
+	Get(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error)
+	Execute(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error)
+	Reject(ctx context.Context, request RejectDeletionRequestRequest) (entity.DeletionRequest, error)
+}
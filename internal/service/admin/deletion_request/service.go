@@ -0,0 +1,100 @@
+package deletion_request
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/execute_deletion"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+)
+
+type service struct {
+	timeProvider provider.TimeProvider
+
+	deleteRequestRepository delete_request.Repository
+	executeDeletionService  execute_deletion.Service
+}
+
+func NewService(
+	timeProvider provider.TimeProvider,
+	deleteRequestRepository delete_request.Repository,
+	executeDeletionService execute_deletion.Service,
+) Service {
+	return &service{
+		timeProvider:            timeProvider,
+		deleteRequestRepository: deleteRequestRepository,
+		executeDeletionService:  executeDeletionService,
+	}
+}
+
+func (s *service) List(ctx context.Context, request ListDeletionRequestsRequest) (ListDeletionRequestsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return ListDeletionRequestsResponse{}, err
+	}
+
+	requests, total, err := s.deleteRequestRepository.List(ctx, delete_request.Filter{
+		Status:     entity.DeletionRequestStatus(request.Status),
+		CustomerId: request.CustomerId,
+		From:       request.From,
+		To:         request.To,
+		Limit:      request.PageSize,
+		Offset:     (request.Page - 1) * request.PageSize,
+	})
+	if err != nil {
+		return ListDeletionRequestsResponse{}, err
+	}
+
+	return ListDeletionRequestsResponse{
+		Items:      requests,
+		Page:       request.Page,
+		PageSize:   request.PageSize,
+		TotalItems: total,
+		TotalPages: (total + request.PageSize - 1) / request.PageSize,
+	}, nil
+}
+
+func (s *service) Get(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error) {
+	if err := request.Validate(); err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	return s.deleteRequestRepository.GetById(ctx, request.Id)
+}
+
+func (s *service) Execute(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error) {
+	deleteRequest, err := s.Get(ctx, request)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	if !deleteRequest.CanBeExecuted(s.timeProvider.GetTime()) {
+		return entity.DeletionRequest{}, custom_error.ErrDeletionRequestCannotBeExecuted
+	}
+
+	return s.executeDeletionService.ExecuteById(ctx, deleteRequest.Id)
+}
+
+func (s *service) Reject(ctx context.Context, request RejectDeletionRequestRequest) (entity.DeletionRequest, error) {
+	if err := request.Validate(); err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	deleteRequest, err := s.deleteRequestRepository.GetById(ctx, request.Id)
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	if !deleteRequest.CanBeRejected() {
+		return entity.DeletionRequest{}, custom_error.ErrDeletionRequestCannotBeRejected
+	}
+
+	deleteRequest.Reject(request.Reason, s.timeProvider.GetTime())
+
+	if err := s.deleteRequestRepository.Reject(ctx, deleteRequest.Id, deleteRequest.RejectionReason, *deleteRequest.RejectedAt); err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	return deleteRequest, nil
+}
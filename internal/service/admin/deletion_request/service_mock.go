@@ -0,0 +1,141 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package deletion_request
+
+import (
+	context "context"
+
+	entity "github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: ctx, request
+func (_m *MockService) Execute(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, DeletionRequestRequest) (entity.DeletionRequest, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, DeletionRequestRequest) entity.DeletionRequest); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(entity.DeletionRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, DeletionRequestRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *MockService) Get(ctx context.Context, request DeletionRequestRequest) (entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, DeletionRequestRequest) (entity.DeletionRequest, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, DeletionRequestRequest) entity.DeletionRequest); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(entity.DeletionRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, DeletionRequestRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *MockService) List(ctx context.Context, request ListDeletionRequestsRequest) (ListDeletionRequestsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 ListDeletionRequestsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListDeletionRequestsRequest) (ListDeletionRequestsResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListDeletionRequestsRequest) ListDeletionRequestsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(ListDeletionRequestsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListDeletionRequestsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: ctx, request
+func (_m *MockService) Reject(ctx context.Context, request RejectDeletionRequestRequest) (entity.DeletionRequest, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 entity.DeletionRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, RejectDeletionRequestRequest) (entity.DeletionRequest, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, RejectDeletionRequestRequest) entity.DeletionRequest); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(entity.DeletionRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, RejectDeletionRequestRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
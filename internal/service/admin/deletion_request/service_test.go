@@ -0,0 +1,367 @@
+package deletion_request_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/admin/deletion_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/execute_deletion"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const requestId = "733f1ba6-1f62-4495-bf33-6f181fdf1030"
+
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
+func TestService_List(t *testing.T) {
+	t.Run("Should list the deletion requests with the default pagination", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("List", ctx, delete_request.Filter{
+			Limit:  20,
+			Offset: 0,
+		}).
+			Return([]entity.DeletionRequest{{Id: "id-1"}}, 1, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		res, err := service.List(ctx, deletion_request.ListDeletionRequestsRequest{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, deletion_request.ListDeletionRequestsResponse{
+			Items:      []entity.DeletionRequest{{Id: "id-1"}},
+			Page:       1,
+			PageSize:   20,
+			TotalItems: 1,
+			TotalPages: 1,
+		}, res)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should list the deletion requests with the filters", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("List", ctx, delete_request.Filter{
+			Status:     entity.DeletionRequestStatusFailed,
+			CustomerId: requestId,
+			From:       now.Add(-24 * time.Hour),
+			To:         now,
+			Limit:      10,
+			Offset:     20,
+		}).
+			Return([]entity.DeletionRequest{{Id: "id-1"}}, 21, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		res, err := service.List(ctx, deletion_request.ListDeletionRequestsRequest{
+			Status:     "failed",
+			CustomerId: requestId,
+			From:       now.Add(-24 * time.Hour),
+			To:         now,
+			Page:       3,
+			PageSize:   10,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.Page)
+		assert.Equal(t, 21, res.TotalItems)
+		assert.Equal(t, 3, res.TotalPages)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the filters are not valid", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			request deletion_request.ListDeletionRequestsRequest
+		}{
+			{"unknown status", deletion_request.ListDeletionRequestsRequest{Status: "unknown"}},
+			{"invalid customer id", deletion_request.ListDeletionRequestsRequest{CustomerId: "abc"}},
+			{"page size too large", deletion_request.ListDeletionRequestsRequest{PageSize: 101}},
+			{"date range inverted", deletion_request.ListDeletionRequestsRequest{From: now, To: now.Add(-time.Hour)}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				ctx := context.Background()
+
+				deleteRequestRepository := delete_request.NewMockRepository(t)
+				executeDeletionService := execute_deletion.NewMockService(t)
+
+				service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+				// Act
+				_, err := service.List(ctx, tc.request)
+
+				// Assert
+				assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+				deleteRequestRepository.AssertExpectations(t)
+				executeDeletionService.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("Should return error when try to list the deletion requests", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("List", ctx, mock.Anything).
+			Return(nil, 0, errors.New("error"))
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.List(ctx, deletion_request.ListDeletionRequestsRequest{})
+
+		// Assert
+		assert.Error(t, err)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+}
+
+func TestService_Get(t *testing.T) {
+	t.Run("Should get the deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId}, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		res, err := service.Get(ctx, deletion_request.DeletionRequestRequest{Id: requestId})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, requestId, res.Id)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the id is not valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Get(ctx, deletion_request.DeletionRequestRequest{Id: "abc"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+}
+
+func TestService_Execute(t *testing.T) {
+	t.Run("Should execute the deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusFailed}, nil)
+
+		executeDeletionService.On("ExecuteById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusExecuted}, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		res, err := service.Execute(ctx, deletion_request.DeletionRequestRequest{Id: requestId})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DeletionRequestStatusExecuted, res.Status)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the deletion request is being executed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{
+				Id:          requestId,
+				Status:      entity.DeletionRequestStatusExecuting,
+				LockedUntil: now.Add(time.Minute),
+			}, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Execute(ctx, deletion_request.DeletionRequestRequest{Id: requestId})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeExecuted)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the deletion request is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestNotFound)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Execute(ctx, deletion_request.DeletionRequestRequest{Id: requestId})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestNotFound)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+}
+
+func TestService_Reject(t *testing.T) {
+	t.Run("Should reject the deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusPending}, nil)
+
+		deleteRequestRepository.On("Reject", ctx, requestId, "legal hold", now).
+			Return(nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		res, err := service.Reject(ctx, deletion_request.RejectDeletionRequestRequest{
+			Id:     requestId,
+			Reason: "legal hold",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DeletionRequestStatusRejected, res.Status)
+		assert.Equal(t, "legal hold", res.RejectionReason)
+		assert.Equal(t, &now, res.RejectedAt)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the deletion request is not pending", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusExecuted}, nil)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Reject(ctx, deletion_request.RejectDeletionRequestRequest{
+			Id:     requestId,
+			Reason: "legal hold",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeRejected)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the reason is missing", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Reject(ctx, deletion_request.RejectDeletionRequestRequest{
+			Id: requestId,
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to reject the deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+		executeDeletionService := execute_deletion.NewMockService(t)
+
+		deleteRequestRepository.On("GetById", ctx, requestId).
+			Return(entity.DeletionRequest{Id: requestId, Status: entity.DeletionRequestStatusPending}, nil)
+
+		deleteRequestRepository.On("Reject", ctx, requestId, mock.Anything, mock.Anything).
+			Return(errors.New("error"))
+
+		service := deletion_request.NewService(timeProvider, deleteRequestRepository, executeDeletionService)
+
+		// Act
+		_, err := service.Reject(ctx, deletion_request.RejectDeletionRequestRequest{
+			Id:     requestId,
+			Reason: "legal hold",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		deleteRequestRepository.AssertExpectations(t)
+		executeDeletionService.AssertExpectations(t)
+	})
+}
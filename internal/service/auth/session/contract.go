@@ -0,0 +1,58 @@
+package session
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
+)
+
+const TokenTypeBearer = "Bearer"
+
+type LoginRequest struct {
+	DocumentId string `json:"document_id"`
+	Password   string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *RefreshRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+// LogoutRequest revokes the given refresh token, or every refresh token of
+// the customer when All is set.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required_without=All"`
+	All          bool   `json:"all"`
+}
+
+func (r *LogoutRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+// TokenResponse follows the token response of RFC 6749, the expiration is
+// in seconds.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type Service interface {
+	Login(ctx context.Context, request LoginRequest) (TokenResponse, error)
+	Anonymous(ctx context.Context) (TokenResponse, error)
+	Refresh(ctx context.Context, request RefreshRequest) (TokenResponse, error)
+	Logout(ctx context.Context, customerId string, request LogoutRequest) error
+}
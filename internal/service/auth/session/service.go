@@ -0,0 +1,213 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/refresh_token"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+)
+
+type service struct {
+	config *environment.AuthConfig
+
+	timeProvider  provider.TimeProvider
+	tokenProvider provider.TokenProvider
+
+	credentialsService  credentials.Service
+	registrationService registration.Service
+
+	customerRepository     customer.Repository
+	refreshTokenRepository refresh_token.Repository
+}
+
+func NewService(
+	config *environment.AuthConfig,
+	timeProvider provider.TimeProvider,
+	tokenProvider provider.TokenProvider,
+	credentialsService credentials.Service,
+	registrationService registration.Service,
+	customerRepository customer.Repository,
+	refreshTokenRepository refresh_token.Repository,
+) Service {
+	return &service{
+		config:                 config,
+		timeProvider:           timeProvider,
+		tokenProvider:          tokenProvider,
+		credentialsService:     credentialsService,
+		registrationService:    registrationService,
+		customerRepository:     customerRepository,
+		refreshTokenRepository: refreshTokenRepository,
+	}
+}
+
+func (s *service) Login(ctx context.Context, request LoginRequest) (TokenResponse, error) {
+	customer, err := s.credentialsService.VerifyCredentials(ctx, credentials.VerifyCredentialsRequest{
+		DocumentId: request.DocumentId,
+		Password:   request.Password,
+	})
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	return s.issue(ctx, customer.Id)
+}
+
+// Anonymous registers a new anonymous customer, the tokens are the only way
+// back to it, there are no credentials to log in again.
+func (s *service) Anonymous(ctx context.Context) (TokenResponse, error) {
+	customer, err := s.registrationService.Register(ctx, registration.RegisterCustomerRequest{
+		IsAnonymous: true,
+	})
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	return s.issue(ctx, customer.Id)
+}
+
+// Refresh exchanges the refresh token by a new pair of tokens. A refresh
+// token is used only once, presenting a revoked one means it was leaked, so
+// every refresh token of the customer is revoked.
+func (s *service) Refresh(ctx context.Context, request RefreshRequest) (TokenResponse, error) {
+	if err := request.Validate(); err != nil {
+		return TokenResponse{}, err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	token, err := s.refreshTokenRepository.GetByHash(ctx, s.tokenProvider.HashRefreshToken(request.RefreshToken))
+	if err == custom_error.ErrRefreshTokenNotFound {
+		return TokenResponse{}, custom_error.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	if token.IsRevoked() {
+		logger.FromContext(ctx).WarnContext(ctx, "revoked refresh token reused, revoking every refresh token of the customer", "customer_id", token.CustomerId, "token_id", token.Id)
+
+		if err := s.refreshTokenRepository.RevokeAllByCustomerId(ctx, token.CustomerId, now); err != nil {
+			return TokenResponse{}, err
+		}
+
+		return TokenResponse{}, custom_error.ErrInvalidRefreshToken
+	}
+
+	if token.IsExpired(now) {
+		return TokenResponse{}, custom_error.ErrInvalidRefreshToken
+	}
+
+	// the customer may have been deleted since the token was issued
+	if _, err := s.customerRepository.Get(ctx, token.CustomerId); err != nil {
+		if err == custom_error.ErrCustomerNotFound {
+			return TokenResponse{}, custom_error.ErrInvalidRefreshToken
+		}
+
+		return TokenResponse{}, err
+	}
+
+	response, next, err := s.newTokens(token.CustomerId, now)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	if err := s.refreshTokenRepository.Rotate(ctx, token.Id, next, now); err != nil {
+		if err == custom_error.ErrRefreshTokenNotFound {
+			// exchanged by a concurrent request
+			return TokenResponse{}, custom_error.ErrInvalidRefreshToken
+		}
+
+		return TokenResponse{}, err
+	}
+
+	return response, nil
+}
+
+// Logout revokes the refresh tokens, the access tokens already issued are
+// short lived and remain valid until they expire.
+func (s *service) Logout(ctx context.Context, customerId string, request LogoutRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	if request.All {
+		return s.refreshTokenRepository.RevokeAllByCustomerId(ctx, customerId, now)
+	}
+
+	token, err := s.refreshTokenRepository.GetByHash(ctx, s.tokenProvider.HashRefreshToken(request.RefreshToken))
+	if err == custom_error.ErrRefreshTokenNotFound {
+		return custom_error.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if token.CustomerId != customerId {
+		return custom_error.ErrInvalidRefreshToken
+	}
+
+	if token.IsRevoked() {
+		return nil
+	}
+
+	err = s.refreshTokenRepository.Revoke(ctx, token.Id, now)
+	if err == custom_error.ErrRefreshTokenNotFound {
+		// revoked by a concurrent request
+		return nil
+	}
+
+	return err
+}
+
+func (s *service) issue(ctx context.Context, customerId string) (TokenResponse, error) {
+	response, token, err := s.newTokens(customerId, s.timeProvider.GetTime())
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	if err := s.refreshTokenRepository.Create(ctx, token); err != nil {
+		return TokenResponse{}, err
+	}
+
+	return response, nil
+}
+
+// newTokens returns the response sent to the customer and the refresh token
+// to be stored, which only holds the hash of the one in the response.
+func (s *service) newTokens(customerId string, now time.Time) (TokenResponse, entity.RefreshToken, error) {
+	accessToken, err := s.tokenProvider.IssueAccessToken(customerId, now)
+	if err != nil {
+		return TokenResponse{}, entity.RefreshToken{}, err
+	}
+
+	refreshToken, err := s.tokenProvider.NewRefreshToken()
+	if err != nil {
+		return TokenResponse{}, entity.RefreshToken{}, err
+	}
+
+	token := entity.NewRefreshToken(customerId,
+		s.tokenProvider.HashRefreshToken(refreshToken),
+		now,
+		s.config.RefreshTokenTtl)
+
+	response := TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    int(s.config.AccessTokenTtl.Seconds()),
+		RefreshToken: refreshToken,
+	}
+
+	return response, token, nil
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package session
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Anonymous provides a mock function with given fields: ctx
+func (_m *MockService) Anonymous(ctx context.Context) (TokenResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymous")
+	}
+
+	var r0 TokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (TokenResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) TokenResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(TokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, request
+func (_m *MockService) Login(ctx context.Context, request LoginRequest) (TokenResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 TokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, LoginRequest) (TokenResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, LoginRequest) TokenResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(TokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, LoginRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, customerId, request
+func (_m *MockService) Logout(ctx context.Context, customerId string, request LogoutRequest) error {
+	ret := _m.Called(ctx, customerId, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, LogoutRequest) error); ok {
+		r0 = rf(ctx, customerId, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, request
+func (_m *MockService) Refresh(ctx context.Context, request RefreshRequest) (TokenResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 TokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, RefreshRequest) (TokenResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, RefreshRequest) TokenResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(TokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, RefreshRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,560 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/refresh_token"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/auth/session"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
+var config = &environment.AuthConfig{
+	AccessTokenTtl:  15 * time.Minute,
+	RefreshTokenTtl: 720 * time.Hour,
+}
+
+type dependencies struct {
+	tokenProvider          *provider.MockTokenProvider
+	credentialsService     *credentials.MockService
+	registrationService    *registration.MockService
+	customerRepository     *customer.MockRepository
+	refreshTokenRepository *refresh_token.MockRepository
+}
+
+func newService(t *testing.T) (session.Service, dependencies) {
+	deps := dependencies{
+		tokenProvider:          provider.NewMockTokenProvider(t),
+		credentialsService:     credentials.NewMockService(t),
+		registrationService:    registration.NewMockService(t),
+		customerRepository:     customer.NewMockRepository(t),
+		refreshTokenRepository: refresh_token.NewMockRepository(t),
+	}
+
+	service := session.NewService(config,
+		timeProvider,
+		deps.tokenProvider,
+		deps.credentialsService,
+		deps.registrationService,
+		deps.customerRepository,
+		deps.refreshTokenRepository)
+
+	return service, deps
+}
+
+// expectNewTokens sets the expectations to issue a new pair of tokens, the
+// refresh token is "new-refresh-token" and its hash "new-hash".
+func expectNewTokens(deps dependencies, customerId string) {
+	deps.tokenProvider.On("IssueAccessToken", customerId, now).Return("access-token", nil)
+	deps.tokenProvider.On("NewRefreshToken").Return("new-refresh-token", nil)
+	deps.tokenProvider.On("HashRefreshToken", "new-refresh-token").Return("new-hash")
+}
+
+func isNewRefreshToken(customerId string) interface{} {
+	return mock.MatchedBy(func(token entity.RefreshToken) bool {
+		return token.Id != "" &&
+			token.CustomerId == customerId &&
+			token.TokenHash == "new-hash" &&
+			token.ExpiresAt.Equal(now.Add(720*time.Hour)) &&
+			!token.IsRevoked()
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	t.Run("Should issue the tokens when the credentials are valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.credentialsService.On("VerifyCredentials", ctx, credentials.VerifyCredentialsRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		}).
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Create", ctx, isNewRefreshToken("customer-1")).
+			Return(nil)
+
+		// Act
+		res, err := service.Login(ctx, session.LoginRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, session.TokenResponse{
+			AccessToken:  "access-token",
+			TokenType:    "Bearer",
+			ExpiresIn:    900,
+			RefreshToken: "new-refresh-token",
+		}, res)
+		deps.credentialsService.AssertExpectations(t)
+		deps.tokenProvider.AssertExpectations(t)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the credentials are not valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.credentialsService.On("VerifyCredentials", ctx, mock.Anything).
+			Return(entity.Customer{}, custom_error.ErrInvalidCredentials)
+
+		// Act
+		_, err := service.Login(ctx, session.LoginRequest{
+			DocumentId: "52998224725",
+			Password:   "wrong-password",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		deps.credentialsService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to issue the access token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.credentialsService.On("VerifyCredentials", ctx, mock.Anything).
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		deps.tokenProvider.On("IssueAccessToken", "customer-1", now).
+			Return("", errors.New("error"))
+
+		// Act
+		_, err := service.Login(ctx, session.LoginRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		deps.tokenProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to store the refresh token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.credentialsService.On("VerifyCredentials", ctx, mock.Anything).
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Create", ctx, mock.Anything).
+			Return(errors.New("error"))
+
+		// Act
+		_, err := service.Login(ctx, session.LoginRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+}
+
+func TestService_Anonymous(t *testing.T) {
+	t.Run("Should register an anonymous customer and issue the tokens", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.registrationService.On("Register", ctx, registration.RegisterCustomerRequest{IsAnonymous: true}).
+			Return(registration.CustomerResponse{Id: "customer-1", IsAnonymous: true}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Create", ctx, isNewRefreshToken("customer-1")).
+			Return(nil)
+
+		// Act
+		res, err := service.Anonymous(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", res.AccessToken)
+		assert.Equal(t, "new-refresh-token", res.RefreshToken)
+		deps.registrationService.AssertExpectations(t)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to register the customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.registrationService.On("Register", ctx, mock.Anything).
+			Return(registration.CustomerResponse{}, errors.New("error"))
+
+		// Act
+		_, err := service.Anonymous(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		deps.registrationService.AssertExpectations(t)
+	})
+}
+
+func TestService_Refresh(t *testing.T) {
+	activeToken := entity.RefreshToken{
+		Id:         "token-1",
+		CustomerId: "customer-1",
+		TokenHash:  "hash",
+		ExpiresAt:  now.Add(time.Hour),
+	}
+
+	t.Run("Should rotate the refresh token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(activeToken, nil)
+
+		deps.customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Rotate", ctx, "token-1", isNewRefreshToken("customer-1"), now).
+			Return(nil)
+
+		// Act
+		res, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", res.AccessToken)
+		assert.Equal(t, "new-refresh-token", res.RefreshToken)
+		deps.tokenProvider.AssertExpectations(t)
+		deps.customerRepository.AssertExpectations(t)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the refresh token is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{}, custom_error.ErrRefreshTokenNotFound)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should revoke every refresh token when a revoked one is reused", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		revokedAt := now.Add(-time.Minute)
+		revokedToken := activeToken
+		revokedToken.RevokedAt = &revokedAt
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(revokedToken, nil)
+
+		deps.refreshTokenRepository.On("RevokeAllByCustomerId", ctx, "customer-1", now).
+			Return(nil)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the refresh token is expired", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		expiredToken := activeToken
+		expiredToken.ExpiresAt = now
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(expiredToken, nil)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer no longer exists", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(activeToken, nil)
+
+		deps.customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the refresh token was exchanged concurrently", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(activeToken, nil)
+
+		deps.customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Rotate", ctx, "token-1", mock.Anything, now).
+			Return(custom_error.ErrRefreshTokenNotFound)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to rotate the refresh token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(activeToken, nil)
+
+		deps.customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		expectNewTokens(deps, "customer-1")
+
+		deps.refreshTokenRepository.On("Rotate", ctx, "token-1", mock.Anything, now).
+			Return(errors.New("error"))
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the refresh token is empty", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, _ := newService(t)
+
+		// Act
+		_, err := service.Refresh(ctx, session.RefreshRequest{})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	t.Run("Should revoke the refresh token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{Id: "token-1", CustomerId: "customer-1"}, nil)
+
+		deps.refreshTokenRepository.On("Revoke", ctx, "token-1", now).
+			Return(nil)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.NoError(t, err)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should revoke every refresh token of the customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.refreshTokenRepository.On("RevokeAllByCustomerId", ctx, "customer-1", now).
+			Return(nil)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{All: true})
+
+		// Assert
+		assert.NoError(t, err)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should not revoke a refresh token of another customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{Id: "token-1", CustomerId: "customer-2"}, nil)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should do nothing when the refresh token is already revoked", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		revokedAt := now.Add(-time.Minute)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{Id: "token-1", CustomerId: "customer-1", RevokedAt: &revokedAt}, nil)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.NoError(t, err)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the refresh token is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{}, custom_error.ErrRefreshTokenNotFound)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidRefreshToken)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to revoke the refresh token", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, deps := newService(t)
+
+		deps.tokenProvider.On("HashRefreshToken", "refresh-token").Return("hash")
+
+		deps.refreshTokenRepository.On("GetByHash", ctx, "hash").
+			Return(entity.RefreshToken{Id: "token-1", CustomerId: "customer-1"}, nil)
+
+		deps.refreshTokenRepository.On("Revoke", ctx, "token-1", now).
+			Return(errors.New("error"))
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Assert
+		assert.Error(t, err)
+		deps.refreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when there is nothing to revoke", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, _ := newService(t)
+
+		// Act
+		err := service.Logout(ctx, "customer-1", session.LogoutRequest{})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+	})
+}
@@ -0,0 +1,28 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
+)
+
+type VerifyCredentialsRequest struct {
+	DocumentId string `json:"document_id" validate:"required,document"`
+	Password   string `json:"password" validate:"required,maxbytes=72"`
+}
+
+func (r *VerifyCredentialsRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type Service interface {
+	VerifyCredentials(ctx context.Context, request VerifyCredentialsRequest) (entity.Customer, error)
+	VerifyPassword(customer entity.Customer, password string) error
+	MigratePlaintextPasswords(ctx context.Context) (int, error)
+}
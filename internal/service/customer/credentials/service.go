@@ -0,0 +1,196 @@
+package credentials
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+)
+
+type service struct {
+	config *environment.PasswordConfig
+
+	timeProvider     provider.TimeProvider
+	passwordProvider provider.PasswordProvider
+
+	customerRepository customer.Repository
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+func NewService(
+	config *environment.PasswordConfig,
+	timeProvider provider.TimeProvider,
+	passwordProvider provider.PasswordProvider,
+	customerRepository customer.Repository,
+) Service {
+	return &service{
+		config:             config,
+		timeProvider:       timeProvider,
+		passwordProvider:   passwordProvider,
+		customerRepository: customerRepository,
+	}
+}
+
+// VerifyCredentials returns the customer that owns the document when the
+// password matches. The same error is returned whether the customer exists
+// or not, so the endpoints built on top of it do not leak which documents
+// are registered.
+func (s *service) VerifyCredentials(ctx context.Context, request VerifyCredentialsRequest) (entity.Customer, error) {
+	if err := request.Validate(); err != nil {
+		return entity.Customer{}, err
+	}
+
+	customer, err := s.customerRepository.GetByDocument(ctx, document.Normalize(request.DocumentId))
+	if err == custom_error.ErrCustomerNotFound {
+		// spends the same time as a real verification to not tell apart the
+		// unknown documents by the response time
+		s.verifyDummy(request.Password)
+		return entity.Customer{}, custom_error.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return entity.Customer{}, err
+	}
+
+	if err := s.VerifyPassword(customer, request.Password); err != nil {
+		return entity.Customer{}, err
+	}
+
+	if !s.passwordProvider.IsHash(customer.Password) || s.passwordProvider.NeedsRehash(customer.Password) {
+		// the login must not fail because of the hashing, it is retried on
+		// the next login
+		if err := s.updatePassword(ctx, &customer, request.Password); err != nil {
+			logger.FromContext(ctx).WarnContext(ctx, "error hashing the password", "customer_id", customer.Id, "error", err)
+		}
+	}
+
+	return customer, nil
+}
+
+// VerifyPassword checks the password of a customer already loaded, it does
+// not hash the password again, so the customer is left untouched.
+func (s *service) VerifyPassword(customer entity.Customer, password string) error {
+	if customer.Password == "" {
+		return custom_error.ErrInvalidCredentials
+	}
+
+	// stored before the passwords were hashed
+	if !s.passwordProvider.IsHash(customer.Password) {
+		if subtle.ConstantTimeCompare([]byte(customer.Password), []byte(password)) != 1 {
+			return custom_error.ErrInvalidCredentials
+		}
+
+		return nil
+	}
+
+	valid, err := s.passwordProvider.Verify(customer.Password, password)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return custom_error.ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+func (s *service) verifyDummy(password string) {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHash, _ = s.passwordProvider.Hash("dummy-password")
+	})
+
+	_, _ = s.passwordProvider.Verify(s.dummyHash, password)
+}
+
+func (s *service) updatePassword(ctx context.Context, customer *entity.Customer, password string) error {
+	hash, err := s.passwordProvider.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return s.saveHash(ctx, customer, hash)
+}
+
+func (s *service) saveHash(ctx context.Context, customer *entity.Customer, hash string) error {
+	updated := *customer
+	updated.Password = hash
+	updated.UpdatedAt = s.timeProvider.GetTime()
+
+	if err := s.customerRepository.Update(ctx, updated); err != nil {
+		return err
+	}
+
+	updated.Version++
+	*customer = updated
+
+	return nil
+}
+
+// MigratePlaintextPasswords hashes, in batches, the passwords stored before
+// the passwords were hashed, and returns how many of them were migrated. The
+// passwords that cannot be hashed, as the ones longer than bcrypt accepts,
+// are logged and left as they are.
+func (s *service) MigratePlaintextPasswords(ctx context.Context) (int, error) {
+	migrated := 0
+	skipped := 0
+	afterId := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return migrated, err
+		}
+
+		customers, err := s.customerRepository.ListWithPassword(ctx, afterId, s.config.MigrateBatchSize)
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, customer := range customers {
+			afterId = customer.Id
+
+			if s.passwordProvider.IsHash(customer.Password) {
+				continue
+			}
+
+			hash, err := s.passwordProvider.Hash(customer.Password)
+			if err != nil {
+				logger.FromContext(ctx).WarnContext(ctx, "plaintext password could not be hashed, skipping it", "customer_id", customer.Id, "error", err)
+				skipped++
+				continue
+			}
+
+			err = s.saveHash(ctx, &customer, hash)
+			if err == custom_error.ErrCustomerVersionMismatch {
+				// changed or deleted since it was listed, a password still not
+				// hashed is hashed on the next login or the next migration
+				continue
+			}
+
+			if err != nil {
+				return migrated, err
+			}
+
+			migrated++
+		}
+
+		if len(customers) < s.config.MigrateBatchSize {
+			break
+		}
+	}
+
+	if skipped > 0 {
+		logger.FromContext(ctx).WarnContext(ctx, "plaintext passwords left behind", "skipped", skipped)
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,603 @@
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
+var config = &environment.PasswordConfig{
+	HashCost:         12,
+	MigrateBatchSize: 2,
+}
+
+// withPassword matches the customer updated with the new password.
+func withPassword(customerId string, password string) interface{} {
+	return mock.MatchedBy(func(customer entity.Customer) bool {
+		return customer.Id == customerId &&
+			customer.Password == password &&
+			customer.UpdatedAt.Equal(now)
+	})
+}
+
+var request = credentials.VerifyCredentialsRequest{
+	DocumentId: "529.982.247-25",
+	Password:   "12345678",
+}
+
+func TestService_VerifyCredentials(t *testing.T) {
+	t.Run("Should return the customer when the password matches", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "hashed-password"}, nil)
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+		passwordProvider.On("Verify", "hashed-password", "12345678").Return(true, nil)
+		passwordProvider.On("NeedsRehash", "hashed-password").Return(false)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "customer-1", res.Id)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should rehash the password when the cost has changed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "old-hashed-password", Version: 1}, nil)
+
+		passwordProvider.On("IsHash", "old-hashed-password").Return(true)
+		passwordProvider.On("Verify", "old-hashed-password", "12345678").Return(true, nil)
+		passwordProvider.On("NeedsRehash", "old-hashed-password").Return(true)
+		passwordProvider.On("Hash", "12345678").Return("new-hashed-password", nil)
+
+		customerRepository.On("Update", ctx, withPassword("customer-1", "new-hashed-password")).
+			Return(nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new-hashed-password", res.Password)
+		assert.Equal(t, 2, res.Version)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not fail the verification when the rehash fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "old-hashed-password"}, nil)
+
+		passwordProvider.On("IsHash", "old-hashed-password").Return(true)
+		passwordProvider.On("Verify", "old-hashed-password", "12345678").Return(true, nil)
+		passwordProvider.On("NeedsRehash", "old-hashed-password").Return(true)
+		passwordProvider.On("Hash", "12345678").Return("new-hashed-password", nil)
+
+		customerRepository.On("Update", ctx, withPassword("customer-1", "new-hashed-password")).
+			Return(errors.New("error"))
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "customer-1", res.Id)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should hash a plaintext password when it matches", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "12345678"}, nil)
+
+		passwordProvider.On("IsHash", "12345678").Return(false)
+		passwordProvider.On("Hash", "12345678").Return("hashed-password", nil)
+
+		customerRepository.On("Update", ctx, withPassword("customer-1", "hashed-password")).
+			Return(nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "hashed-password", res.Password)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the plaintext password does not match", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "87654321"}, nil)
+
+		passwordProvider.On("IsHash", "87654321").Return(false)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the password does not match", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "hashed-password"}, nil)
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+		passwordProvider.On("Verify", "hashed-password", "12345678").Return(false, nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return the same error when the customer is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		passwordProvider.On("Hash", mock.Anything).Return("dummy-hash", nil).Once()
+		passwordProvider.On("Verify", "dummy-hash", "12345678").Return(false, nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+		_, otherErr := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		assert.ErrorIs(t, otherErr, custom_error.ErrInvalidCredentials)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer has no password", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to get the customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, errors.New("error"))
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to verify the password", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", Password: "hashed-password"}, nil)
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+		passwordProvider.On("Verify", "hashed-password", "12345678").Return(false, errors.New("error"))
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.VerifyCredentials(ctx, request)
+
+		// Assert
+		assert.Error(t, err)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the request is not valid", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			request credentials.VerifyCredentialsRequest
+		}{
+			{name: "empty document", request: credentials.VerifyCredentialsRequest{Password: "12345678"}},
+			{name: "invalid document", request: credentials.VerifyCredentialsRequest{DocumentId: "12345678900", Password: "12345678"}},
+			{name: "empty password", request: credentials.VerifyCredentialsRequest{DocumentId: "52998224725"}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				ctx := context.Background()
+
+				customerRepository := customer.NewMockRepository(t)
+				passwordProvider := provider.NewMockPasswordProvider(t)
+
+				service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+				// Act
+				_, err := service.VerifyCredentials(ctx, tc.request)
+
+				// Assert
+				assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+				customerRepository.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestService_VerifyPassword(t *testing.T) {
+	t.Run("Should accept the password matching the hash", func(t *testing.T) {
+		// Arrange
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+		passwordProvider.On("Verify", "hashed-password", "12345678").Return(true, nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		err := service.VerifyPassword(entity.Customer{Id: "customer-1", Password: "hashed-password"}, "12345678")
+
+		// Assert
+		assert.NoError(t, err)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should accept the password matching a plaintext password", func(t *testing.T) {
+		// Arrange
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		passwordProvider.On("IsHash", "12345678").Return(false)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		err := service.VerifyPassword(entity.Customer{Id: "customer-1", Password: "12345678"}, "12345678")
+
+		// Assert
+		assert.NoError(t, err)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should reject the password not matching the hash", func(t *testing.T) {
+		// Arrange
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+		passwordProvider.On("Verify", "hashed-password", "wrong-password").Return(false, nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		err := service.VerifyPassword(entity.Customer{Id: "customer-1", Password: "hashed-password"}, "wrong-password")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should reject any password when the customer has none", func(t *testing.T) {
+		// Arrange
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		err := service.VerifyPassword(entity.Customer{Id: "customer-1"}, "")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		passwordProvider.AssertExpectations(t)
+	})
+}
+
+func TestService_MigratePlaintextPasswords(t *testing.T) {
+	t.Run("Should hash the plaintext passwords in batches", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return([]entity.Customer{
+				{Id: "customer-1", Password: "password-1"},
+				{Id: "customer-2", Password: "hashed-password"},
+			}, nil).
+			Once()
+
+		customerRepository.On("ListWithPassword", ctx, "customer-2", 2).
+			Return([]entity.Customer{
+				{Id: "customer-3", Password: "password-3"},
+			}, nil).
+			Once()
+
+		passwordProvider.On("IsHash", "hashed-password").Return(true)
+
+		for _, id := range []string{"1", "3"} {
+			passwordProvider.On("IsHash", "password-"+id).Return(false)
+			passwordProvider.On("Hash", "password-"+id).Return("hashed-password-"+id, nil)
+			customerRepository.On("Update", ctx, withPassword("customer-"+id, "hashed-password-"+id)).Return(nil)
+		}
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, migrated)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should hash the plaintext passwords that look like a hash", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return([]entity.Customer{
+				{Id: "customer-1", Password: "$2secret"},
+			}, nil).
+			Once()
+
+		passwordProvider.On("IsHash", "$2secret").Return(false)
+		passwordProvider.On("Hash", "$2secret").Return("hashed-password-1", nil)
+		customerRepository.On("Update", ctx, withPassword("customer-1", "hashed-password-1")).Return(nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, migrated)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should skip the customers changed during the migration", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return([]entity.Customer{
+				{Id: "customer-1", Password: "password-1"},
+			}, nil).
+			Once()
+
+		passwordProvider.On("IsHash", "password-1").Return(false)
+		passwordProvider.On("Hash", "password-1").Return("hashed-password-1", nil)
+		customerRepository.On("Update", ctx, withPassword("customer-1", "hashed-password-1")).
+			Return(custom_error.ErrCustomerVersionMismatch)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, migrated)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should skip the passwords that cannot be hashed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return([]entity.Customer{
+				{Id: "customer-1", Password: "password-1"},
+				{Id: "customer-2", Password: "password-2"},
+			}, nil).
+			Once()
+
+		customerRepository.On("ListWithPassword", ctx, "customer-2", 2).
+			Return([]entity.Customer{}, nil).
+			Once()
+
+		passwordProvider.On("IsHash", "password-1").Return(false)
+		passwordProvider.On("Hash", "password-1").Return("", errors.New("password too long"))
+		passwordProvider.On("IsHash", "password-2").Return(false)
+		passwordProvider.On("Hash", "password-2").Return("hashed-password-2", nil)
+		customerRepository.On("Update", ctx, withPassword("customer-2", "hashed-password-2")).Return(nil)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, migrated)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to list the customers", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return(nil, errors.New("error"))
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, migrated)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to save a password", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("ListWithPassword", ctx, "", 2).
+			Return([]entity.Customer{
+				{Id: "customer-1", Password: "password-1"},
+			}, nil)
+
+		passwordProvider.On("IsHash", "password-1").Return(false)
+		passwordProvider.On("Hash", "password-1").Return("hashed-password-1", nil)
+		customerRepository.On("Update", ctx, withPassword("customer-1", "hashed-password-1")).
+			Return(errors.New("error"))
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		migrated, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, migrated)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should stop when the context is done", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		service := credentials.NewService(config, timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.MigratePlaintextPasswords(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+		customerRepository.AssertExpectations(t)
+	})
+}
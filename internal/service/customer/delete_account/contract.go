@@ -2,9 +2,10 @@ package delete_account
 
 import (
 	"context"
+	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
 )
 
 type DeleteAccountRequest struct {
@@ -16,15 +17,24 @@ type DeleteAccountRequest struct {
 }
 
 func (r *DeleteAccountRequest) Validate() error {
-	validator := validator.New()
-
-	if err := validator.Struct(r); err != nil {
+	if err := validation.Default().Struct(r); err != nil {
 		return custom_error.ErrRequestNotValid
 	}
 
 	return nil
 }
 
+type DeleteAccountStatusResponse struct {
+	Id                    string     `json:"id"`
+	CreatedAt             time.Time  `json:"created_at"`
+	Status                string     `json:"status"`
+	Executed              bool       `json:"executed"`
+	ExpectedExecutionDate time.Time  `json:"expected_execution_date"`
+	CancelledAt           *time.Time `json:"cancelled_at,omitempty"`
+}
+
 type Service interface {
 	Delete(ctx context.Context, request DeleteAccountRequest) error
+	GetStatus(ctx context.Context, customerId string) (DeleteAccountStatusResponse, error)
+	Cancel(ctx context.Context, customerId string) error
 }
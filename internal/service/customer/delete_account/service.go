@@ -4,27 +4,47 @@ import (
 	"context"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/metrics"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type service struct {
+	config *environment.DeletionConfig
+
+	timeProvider provider.TimeProvider
+
 	customerRepository      customer.Repository
 	deleteRequestRepository delete_request.Repository
 }
 
 func NewService(
+	config *environment.DeletionConfig,
+	timeProvider provider.TimeProvider,
 	customerRepository customer.Repository,
 	deleteRequestRepository delete_request.Repository,
 ) Service {
 	return &service{
+		config:                  config,
+		timeProvider:            timeProvider,
 		customerRepository:      customerRepository,
 		deleteRequestRepository: deleteRequestRepository,
 	}
 }
 
-func (s *service) Delete(ctx context.Context, request DeleteAccountRequest) error {
+func (s *service) Delete(ctx context.Context, request DeleteAccountRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "delete_account.Service.Delete",
+		trace.WithAttributes(attribute.String("customer.id", request.Id)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	if err := request.Validate(); err != nil {
 		return err
 	}
@@ -38,14 +58,69 @@ func (s *service) Delete(ctx context.Context, request DeleteAccountRequest) erro
 		return err
 	}
 
-	if existingDeleteRequest.Id != "" {
+	if existingDeleteRequest.IsActive() {
+		metrics.DeletionRequestsDuplicated.Inc()
 		return custom_error.ErrDeletionRequestAlreadyCreated
 	}
 
+	now := s.timeProvider.GetTime()
+
 	deleteRequest := entity.NewDeleteRequest(request.Id,
 		request.Name,
 		request.Address,
-		request.Phone)
+		request.Phone,
+		now,
+		s.config.GracePeriod)
+
+	message, err := entity.NewOutboxMessage(entity.NewCustomerDeletionRequestedEvent(deleteRequest, now))
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteRequestRepository.Create(ctx, deleteRequest, message); err != nil {
+		// created concurrently after the check above
+		if err == custom_error.ErrDeletionRequestAlreadyCreated {
+			metrics.DeletionRequestsDuplicated.Inc()
+		}
+
+		return err
+	}
+
+	metrics.DeletionRequestsCreated.Inc()
+
+	return nil
+}
+
+func (s *service) GetStatus(ctx context.Context, customerId string) (DeleteAccountStatusResponse, error) {
+	deleteRequest, err := s.deleteRequestRepository.GetByCustomerId(ctx, customerId)
+	if err != nil {
+		return DeleteAccountStatusResponse{}, err
+	}
+
+	expectedExecutionDate := deleteRequest.ScheduledFor
+	if deleteRequest.ExecutedAt != nil {
+		expectedExecutionDate = *deleteRequest.ExecutedAt
+	}
+
+	return DeleteAccountStatusResponse{
+		Id:                    deleteRequest.Id,
+		CreatedAt:             deleteRequest.CreatedAt,
+		Status:                string(deleteRequest.Status),
+		Executed:              deleteRequest.IsExecuted(),
+		ExpectedExecutionDate: expectedExecutionDate,
+		CancelledAt:           deleteRequest.CancelledAt,
+	}, nil
+}
+
+func (s *service) Cancel(ctx context.Context, customerId string) error {
+	deleteRequest, err := s.deleteRequestRepository.GetByCustomerId(ctx, customerId)
+	if err != nil {
+		return err
+	}
+
+	if !deleteRequest.CanBeCancelled() {
+		return custom_error.ErrDeletionRequestCannotBeCancelled
+	}
 
-	return s.deleteRequestRepository.Create(ctx, deleteRequest)
+	return s.deleteRequestRepository.Cancel(ctx, deleteRequest.Id, s.timeProvider.GetTime())
 }
@@ -13,6 +13,24 @@ type MockService struct {
 	mock.Mock
 }
 
+// Cancel provides a mock function with given fields: ctx, customerId
+func (_m *MockService) Cancel(ctx context.Context, customerId string) error {
+	ret := _m.Called(ctx, customerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, customerId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, request
 func (_m *MockService) Delete(ctx context.Context, request DeleteAccountRequest) error {
 	ret := _m.Called(ctx, request)
@@ -31,6 +49,34 @@ func (_m *MockService) Delete(ctx context.Context, request DeleteAccountRequest)
 	return r0
 }
 
+// GetStatus provides a mock function with given fields: ctx, customerId
+func (_m *MockService) GetStatus(ctx context.Context, customerId string) (DeleteAccountStatusResponse, error) {
+	ret := _m.Called(ctx, customerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 DeleteAccountStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (DeleteAccountStatusResponse, error)); ok {
+		return rf(ctx, customerId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) DeleteAccountStatusResponse); ok {
+		r0 = rf(ctx, customerId)
+	} else {
+		r0 = ret.Get(0).(DeleteAccountStatusResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, customerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
@@ -3,16 +3,36 @@ package delete_account_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/delete_account"
 	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var config = &environment.DeletionConfig{
+	GracePeriod: 720 * time.Hour,
+}
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
 func TestService_Delete(t *testing.T) {
 	t.Run("Should delete a customer", func(t *testing.T) {
 		// Arrange
@@ -21,16 +41,27 @@ func TestService_Delete(t *testing.T) {
 		customerRepository := customer.NewMockRepository(t)
 		deleteRequestRepository := delete_request.NewMockRepository(t)
 
-		customerRepository.On("Get", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
 			Return(entity.Customer{}, nil)
 
-		deleteRequestRepository.On("GetByCustomerId", ctx, mock.Anything).
+		deleteRequestRepository.On("GetByCustomerId", mock.Anything, mock.Anything).
 			Return(entity.DeletionRequest{}, nil)
 
-		deleteRequestRepository.On("Create", ctx, mock.Anything).
+		deleteRequestRepository.On("Create", mock.Anything, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusPending &&
+				request.CreatedAt.Equal(now) &&
+				request.ScheduledFor.Equal(now.Add(720*time.Hour)) &&
+				request.NextAttemptAt.Equal(request.ScheduledFor)
+		}), mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.EventType == entity.EventTypeCustomerDeletionRequested &&
+				message.Status == entity.OutboxMessageStatusPending &&
+				message.CreatedAt.Equal(now)
+		})).
 			Return(nil)
 
-		service := delete_account.NewService(customerRepository, deleteRequestRepository)
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		created := testutil.ToFloat64(metrics.DeletionRequestsCreated)
 
 		// Act
 		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
@@ -42,6 +73,7 @@ func TestService_Delete(t *testing.T) {
 
 		// Assert
 		assert.NoError(t, err)
+		assert.Equal(t, created+1, testutil.ToFloat64(metrics.DeletionRequestsCreated))
 		customerRepository.AssertExpectations(t)
 		deleteRequestRepository.AssertExpectations(t)
 	})
@@ -53,10 +85,10 @@ func TestService_Delete(t *testing.T) {
 		customerRepository := customer.NewMockRepository(t)
 		deleteRequestRepository := delete_request.NewMockRepository(t)
 
-		customerRepository.On("Get", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
 			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
 
-		service := delete_account.NewService(customerRepository, deleteRequestRepository)
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
 
 		// Act
 		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
@@ -79,15 +111,18 @@ func TestService_Delete(t *testing.T) {
 		customerRepository := customer.NewMockRepository(t)
 		deleteRequestRepository := delete_request.NewMockRepository(t)
 
-		customerRepository.On("Get", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
 			Return(entity.Customer{}, nil)
 
-		deleteRequestRepository.On("GetByCustomerId", ctx, mock.Anything).
+		deleteRequestRepository.On("GetByCustomerId", mock.Anything, mock.Anything).
 			Return(entity.DeletionRequest{
-				Id: "id",
+				Id:     "id",
+				Status: entity.DeletionRequestStatusPending,
 			}, nil)
 
-		service := delete_account.NewService(customerRepository, deleteRequestRepository)
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		duplicated := testutil.ToFloat64(metrics.DeletionRequestsDuplicated)
 
 		// Act
 		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
@@ -98,7 +133,78 @@ func TestService_Delete(t *testing.T) {
 		})
 
 		// Assert
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestAlreadyCreated)
+		assert.Equal(t, duplicated+1, testutil.ToFloat64(metrics.DeletionRequestsDuplicated))
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when delete request is created concurrently", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.Customer{}, nil)
+
+		deleteRequestRepository.On("GetByCustomerId", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestNotFound)
+
+		deleteRequestRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).
+			Return(custom_error.ErrDeletionRequestAlreadyCreated)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		duplicated := testutil.ToFloat64(metrics.DeletionRequestsDuplicated)
+
+		// Act
+		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
+			Id:      "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			Name:    "John Doe",
+			Address: "Av. Brasil, 1000",
+			Phone:   "1122334455",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestAlreadyCreated)
+		assert.Equal(t, duplicated+1, testutil.ToFloat64(metrics.DeletionRequestsDuplicated))
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should create a new deletion request when the previous one was executed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.Customer{}, nil)
+
+		deleteRequestRepository.On("GetByCustomerId", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{
+				Id:     "id",
+				Status: entity.DeletionRequestStatusExecuted,
+			}, nil)
+
+		deleteRequestRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
+			Id:      "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			Name:    "John Doe",
+			Address: "Av. Brasil, 1000",
+			Phone:   "1122334455",
+		})
+
+		// Assert
+		assert.NoError(t, err)
 		customerRepository.AssertExpectations(t)
 		deleteRequestRepository.AssertExpectations(t)
 	})
@@ -110,16 +216,50 @@ func TestService_Delete(t *testing.T) {
 		customerRepository := customer.NewMockRepository(t)
 		deleteRequestRepository := delete_request.NewMockRepository(t)
 
-		customerRepository.On("Get", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+		customerRepository.On("Get", mock.Anything, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
 			Return(entity.Customer{}, nil)
 
-		deleteRequestRepository.On("GetByCustomerId", ctx, mock.Anything).
+		deleteRequestRepository.On("GetByCustomerId", mock.Anything, mock.Anything).
 			Return(entity.DeletionRequest{}, nil)
 
-		deleteRequestRepository.On("Create", ctx, mock.Anything).
+		deleteRequestRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).
 			Return(custom_error.ErrRequestNotValid)
 
-		service := delete_account.NewService(customerRepository, deleteRequestRepository)
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
+			Id:      "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			Name:    "John Doe",
+			Address: "Av. Brasil, 1000",
+			Phone:   "1122334455",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should trace the deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		recorder := tracetest.NewSpanRecorder()
+
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		defer otel.SetTracerProvider(previous)
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		customerRepository.On("Get", mock.MatchedBy(func(ctx context.Context) bool {
+			return trace.SpanContextFromContext(ctx).IsValid()
+		}), "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
 
 		// Act
 		err := service.Delete(ctx, delete_account.DeleteAccountRequest{
@@ -131,6 +271,183 @@ func TestService_Delete(t *testing.T) {
 
 		// Assert
 		assert.Error(t, err)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "delete_account.Service.Delete", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		customerRepository.AssertExpectations(t)
+	})
+}
+
+func TestService_GetStatus(t *testing.T) {
+	t.Run("Should return the status of a pending deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		createdAt := time.Now()
+		scheduledFor := createdAt.Add(720 * time.Hour)
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{
+				Id:            "id",
+				CustomerId:    "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+				Status:        entity.DeletionRequestStatusPending,
+				Name:          "John Doe",
+				Address:       "Av. Brasil, 1000",
+				Phone:         "1122334455",
+				NextAttemptAt: scheduledFor.Add(time.Hour),
+				ScheduledFor:  scheduledFor,
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt,
+			}, nil)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.GetStatus(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, delete_account.DeleteAccountStatusResponse{
+			Id:                    "id",
+			CreatedAt:             createdAt,
+			Status:                "pending",
+			Executed:              false,
+			ExpectedExecutionDate: scheduledFor,
+		}, res)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return the status of an executed deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		createdAt := time.Now()
+		executedAt := createdAt.Add(time.Minute)
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{
+				Id:            "id",
+				Status:        entity.DeletionRequestStatusExecuted,
+				NextAttemptAt: createdAt,
+				ExecutedAt:    &executedAt,
+				CreatedAt:     createdAt,
+				UpdatedAt:     executedAt,
+			}, nil)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.GetStatus(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, res.Executed)
+		assert.Equal(t, executedAt, res.ExpectedExecutionDate)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the deletion request is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestNotFound)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.GetStatus(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestNotFound)
+		assert.Empty(t, res)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+}
+
+func TestService_Cancel(t *testing.T) {
+	t.Run("Should cancel a pending deletion request", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{
+				Id:     "id",
+				Status: entity.DeletionRequestStatusPending,
+			}, nil)
+
+		deleteRequestRepository.On("Cancel", ctx, "id", now).
+			Return(nil)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.Cancel(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.NoError(t, err)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the deletion request is not pending", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{
+				Id:     "id",
+				Status: entity.DeletionRequestStatusExecuting,
+			}, nil)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.Cancel(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeCancelled)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the deletion request is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("GetByCustomerId", ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030").
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestNotFound)
+
+		service := delete_account.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.Cancel(ctx, "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestNotFound)
 		customerRepository.AssertExpectations(t)
 		deleteRequestRepository.AssertExpectations(t)
 	})
@@ -0,0 +1,12 @@
+package execute_deletion
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+)
+
+type Service interface {
+	ExecutePending(ctx context.Context) error
+	ExecuteById(ctx context.Context, id string) (entity.DeletionRequest, error)
+}
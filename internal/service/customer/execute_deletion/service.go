@@ -0,0 +1,138 @@
+package execute_deletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/backoff"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/metrics"
+)
+
+type service struct {
+	config *environment.DeletionConfig
+
+	timeProvider provider.TimeProvider
+
+	customerRepository      customer.Repository
+	deleteRequestRepository delete_request.Repository
+}
+
+func NewService(
+	config *environment.DeletionConfig,
+	timeProvider provider.TimeProvider,
+	customerRepository customer.Repository,
+	deleteRequestRepository delete_request.Repository,
+) Service {
+	return &service{
+		config:                  config,
+		timeProvider:            timeProvider,
+		customerRepository:      customerRepository,
+		deleteRequestRepository: deleteRequestRepository,
+	}
+}
+
+func (s *service) ExecutePending(ctx context.Context) error {
+	now := s.timeProvider.GetTime()
+
+	requests, err := s.deleteRequestRepository.ClaimPending(ctx,
+		now,
+		now.Add(s.config.LockDuration),
+		s.config.BatchSize,
+		s.config.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		if request.Status == entity.DeletionRequestStatusFailed {
+			logger.FromContext(ctx).ErrorContext(ctx, "deletion request interrupted on its last attempt", "request_id", request.Id, "attempts", request.Attempts)
+
+			metrics.DeletionRequestAttemptsFailed.Inc()
+			metrics.DeletionRequestsFailed.Inc()
+			continue
+		}
+
+		if _, err := s.execute(ctx, request); err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "error executing deletion request", "request_id", request.Id, "attempts", request.Attempts, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteById executes the request right away, without waiting for the
+// grace period or the retries backoff.
+func (s *service) ExecuteById(ctx context.Context, id string) (entity.DeletionRequest, error) {
+	now := s.timeProvider.GetTime()
+
+	request, err := s.deleteRequestRepository.ClaimById(ctx,
+		id,
+		now,
+		now.Add(s.config.LockDuration))
+	if err != nil {
+		return entity.DeletionRequest{}, err
+	}
+
+	return s.execute(ctx, request)
+}
+
+func (s *service) execute(ctx context.Context, request entity.DeletionRequest) (entity.DeletionRequest, error) {
+	now := s.timeProvider.GetTime()
+
+	// the outcome is only saved while the request is still claimed by this
+	// execution, the lock is changed when the request is marked
+	claimedUntil := request.LockedUntil
+
+	err := s.removeCustomer(ctx, request.CustomerId, now)
+
+	// the customer may have been removed by a previous attempt that could not
+	// flag the request as executed, so a missing customer is not a failure,
+	// unless the request does not know which customer it was
+	if err != nil && (err != custom_error.ErrCustomerNotFound || request.CustomerId == "") {
+		nextAttemptAt := now.Add(backoff.Exponential(s.config.RetryBackoff, s.config.MaxRetryBackoff, request.Attempts))
+
+		request.MarkAsFailed(err, now, nextAttemptAt, s.config.MaxAttempts)
+
+		if err := s.deleteRequestRepository.Update(ctx, request, claimedUntil); err != nil {
+			return request, err
+		}
+
+		metrics.DeletionRequestAttemptsFailed.Inc()
+
+		if request.Status == entity.DeletionRequestStatusFailed {
+			metrics.DeletionRequestsFailed.Inc()
+		}
+
+		return request, err
+	}
+
+	request.MarkAsExecuted(now)
+
+	message, err := entity.NewOutboxMessage(entity.NewCustomerDeletedEvent(request, s.config.Strategy, now))
+	if err != nil {
+		return request, err
+	}
+
+	if err := s.deleteRequestRepository.MarkAsExecuted(ctx, request, claimedUntil, message); err != nil {
+		return request, err
+	}
+
+	metrics.DeletionRequestsExecuted.Inc()
+
+	return request, nil
+}
+
+func (s *service) removeCustomer(ctx context.Context, customerId string, now time.Time) error {
+	if s.config.IsAnonymizationEnabled() {
+		return s.customerRepository.Anonymize(ctx, customerId, now)
+	}
+
+	return s.customerRepository.Delete(ctx, customerId, now)
+}
@@ -0,0 +1,488 @@
+package execute_deletion_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/execute_deletion"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var config = &environment.DeletionConfig{
+	Strategy:        environment.DeletionStrategyDelete,
+	BatchSize:       10,
+	MaxAttempts:     5,
+	LockDuration:    5 * time.Minute,
+	RetryBackoff:    time.Minute,
+	MaxRetryBackoff: time.Hour,
+}
+
+func TestService_ExecutePending(t *testing.T) {
+	t.Run("Should execute the pending deletion requests", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, now, now.Add(5*time.Minute), 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1"},
+				{Id: "id-2", CustomerId: "customer-2"},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", now).
+			Return(nil)
+		customerRepository.On("Delete", ctx, "customer-2", mock.Anything).
+			Return(custom_error.ErrCustomerNotFound)
+
+		deleteRequestRepository.On("MarkAsExecuted", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusExecuted &&
+				request.ExecutedAt.Equal(now) &&
+				request.LastError == ""
+		}), mock.Anything, mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.EventType == entity.EventTypeCustomerDeleted &&
+				strings.Contains(message.Payload, `"strategy":"delete"`)
+		})).
+			Return(nil).
+			Times(2)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		executed := testutil.ToFloat64(metrics.DeletionRequestsExecuted)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, executed+2, testutil.ToFloat64(metrics.DeletionRequestsExecuted))
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should anonymize the customers when anonymization is enabled", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		anonymizeConfig := *config
+		anonymizeConfig.Strategy = environment.DeletionStrategyAnonymize
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1"},
+			}, nil)
+
+		customerRepository.On("Anonymize", ctx, "customer-1", now).
+			Return(nil)
+
+		deleteRequestRepository.On("MarkAsExecuted", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusExecuted
+		}), mock.Anything, mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.CorrelationId == "id-1" &&
+				strings.Contains(message.Payload, `"strategy":"anonymize"`)
+		})).
+			Return(nil)
+
+		service := execute_deletion.NewService(&anonymizeConfig, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should schedule a retry when the customer could not be deleted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1", Attempts: 2},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", mock.Anything).
+			Return(errors.New("connection reset"))
+
+		deleteRequestRepository.On("Update", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusPending &&
+				request.Attempts == 3 &&
+				request.LastError == "connection reset" &&
+				request.NextAttemptAt.Equal(now.Add(4*time.Minute))
+		}), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		attemptsFailed := testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed)
+		failed := testutil.ToFloat64(metrics.DeletionRequestsFailed)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, attemptsFailed+1, testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed))
+		assert.Equal(t, failed, testutil.ToFloat64(metrics.DeletionRequestsFailed))
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should not execute a deletion request that lost the reference to the customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "", Attempts: 0},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, "", mock.Anything).
+			Return(custom_error.ErrCustomerNotFound)
+
+		deleteRequestRepository.On("Update", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusPending &&
+				request.Attempts == 1
+		}), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertNotCalled(t, "MarkAsExecuted", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should flag the deletion request as failed when the attempts are exhausted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1", Status: entity.DeletionRequestStatusExecuting, Attempts: 4},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", mock.Anything).
+			Return(errors.New("error"))
+
+		deleteRequestRepository.On("Update", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusFailed && request.Attempts == 5
+		}), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		attemptsFailed := testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed)
+		failed := testutil.ToFloat64(metrics.DeletionRequestsFailed)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, attemptsFailed+1, testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed))
+		assert.Equal(t, failed+1, testutil.ToFloat64(metrics.DeletionRequestsFailed))
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should report the deletion requests interrupted on their last attempt without executing them", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1", Status: entity.DeletionRequestStatusFailed, Attempts: 5},
+			}, nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		attemptsFailed := testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed)
+		failed := testutil.ToFloat64(metrics.DeletionRequestsFailed)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, attemptsFailed+1, testutil.ToFloat64(metrics.DeletionRequestAttemptsFailed))
+		assert.Equal(t, failed+1, testutil.ToFloat64(metrics.DeletionRequestsFailed))
+		customerRepository.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+		deleteRequestRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should limit the retry backoff", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1", Attempts: 20},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", mock.Anything).
+			Return(errors.New("error"))
+
+		deleteRequestRepository.On("Update", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.NextAttemptAt.Equal(now.Add(time.Hour))
+		}), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should keep going when a deletion request could not be updated", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return([]entity.DeletionRequest{
+				{Id: "id-1", CustomerId: "customer-1"},
+				{Id: "id-2", CustomerId: "customer-2"},
+			}, nil)
+
+		customerRepository.On("Delete", ctx, mock.Anything, mock.Anything).
+			Return(nil).
+			Times(2)
+
+		deleteRequestRepository.On("MarkAsExecuted", ctx, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Times(2)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when try to claim the pending deletion requests", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 10, 5).
+			Return(nil, errors.New("error"))
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		err := service.ExecutePending(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+}
+
+func TestService_ExecuteById(t *testing.T) {
+	t.Run("Should execute the deletion request right away", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimById", ctx, "id-1", now, now.Add(5*time.Minute)).
+			Return(entity.DeletionRequest{Id: "id-1", CustomerId: "customer-1", Status: entity.DeletionRequestStatusExecuting, LockedUntil: now.Add(5 * time.Minute)}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", mock.Anything).
+			Return(nil)
+
+		deleteRequestRepository.On("MarkAsExecuted", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Status == entity.DeletionRequestStatusExecuted
+		}), now.Add(5*time.Minute), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.ExecuteById(ctx, "id-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DeletionRequestStatusExecuted, res.Status)
+		assert.NotNil(t, res.ExecutedAt)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the customer could not be deleted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimById", ctx, "id-1", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{Id: "id-1", CustomerId: "customer-1"}, nil)
+
+		customerRepository.On("Delete", ctx, "customer-1", mock.Anything).
+			Return(errors.New("connection reset"))
+
+		deleteRequestRepository.On("Update", ctx, mock.MatchedBy(func(request entity.DeletionRequest) bool {
+			return request.Attempts == 1 && request.LastError == "connection reset"
+		}), mock.Anything).
+			Return(nil)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.ExecuteById(ctx, "id-1")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 1, res.Attempts)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when the deletion request cannot be claimed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		customerRepository := customer.NewMockRepository(t)
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		deleteRequestRepository.On("ClaimById", ctx, "id-1", mock.Anything, mock.Anything).
+			Return(entity.DeletionRequest{}, custom_error.ErrDeletionRequestCannotBeExecuted)
+
+		service := execute_deletion.NewService(config, timeProvider, customerRepository, deleteRequestRepository)
+
+		// Act
+		res, err := service.ExecuteById(ctx, "id-1")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrDeletionRequestCannotBeExecuted)
+		assert.Empty(t, res)
+		timeProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+		deleteRequestRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
+)
+
+// ProfileResponse is the view of the customer exposed to the customer
+// itself, anonymous customers have no document to show. The version is
+// sent as the ETag header instead of in the body.
+type ProfileResponse struct {
+	Id           string    `json:"id"`
+	DocumentId   string    `json:"document_id,omitempty"`
+	DocumentType string    `json:"document_type,omitempty"`
+	IsAnonymous  bool      `json:"is_anonymous"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Version      int       `json:"-"`
+}
+
+// UpdateProfileRequest changes the mutable fields of the customer, the
+// version comes from the If-Match header and must match the stored one.
+type UpdateProfileRequest struct {
+	Version         int    `json:"-"`
+	CurrentPassword string `json:"current_password" validate:"required,maxbytes=72"`
+	Password        string `json:"password" validate:"required,min=8,maxbytes=72"`
+}
+
+func (r *UpdateProfileRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type Service interface {
+	Get(ctx context.Context, customerId string) (ProfileResponse, error)
+	Update(ctx context.Context, customerId string, request UpdateProfileRequest) (ProfileResponse, error)
+}
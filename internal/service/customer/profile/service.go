@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/mask"
+)
+
+type service struct {
+	timeProvider       provider.TimeProvider
+	passwordProvider   provider.PasswordProvider
+	credentialsService credentials.Service
+	customerRepository customer.Repository
+}
+
+func NewService(
+	timeProvider provider.TimeProvider,
+	passwordProvider provider.PasswordProvider,
+	credentialsService credentials.Service,
+	customerRepository customer.Repository,
+) Service {
+	return &service{
+		timeProvider:       timeProvider,
+		passwordProvider:   passwordProvider,
+		credentialsService: credentialsService,
+		customerRepository: customerRepository,
+	}
+}
+
+func (s *service) Get(ctx context.Context, customerId string) (ProfileResponse, error) {
+	customer, err := s.customerRepository.Get(ctx, customerId)
+	if err != nil {
+		return ProfileResponse{}, err
+	}
+
+	return newProfileResponse(customer), nil
+}
+
+func (s *service) Update(ctx context.Context, customerId string, request UpdateProfileRequest) (ProfileResponse, error) {
+	if err := request.Validate(); err != nil {
+		return ProfileResponse{}, err
+	}
+
+	customer, err := s.customerRepository.Get(ctx, customerId)
+	if err != nil {
+		return ProfileResponse{}, err
+	}
+
+	// fails fast on a stale ETag, the repository checks the version again
+	// when updating to catch the concurrent edits made after this read
+	if customer.Version != request.Version {
+		return ProfileResponse{}, custom_error.ErrCustomerVersionMismatch
+	}
+
+	if customer.IsAnonymous {
+		return ProfileResponse{}, custom_error.ErrCustomerIsAnonymous
+	}
+
+	if err := s.credentialsService.VerifyPassword(customer, request.CurrentPassword); err != nil {
+		return ProfileResponse{}, err
+	}
+
+	password, err := s.passwordProvider.Hash(request.Password)
+	if err != nil {
+		return ProfileResponse{}, err
+	}
+
+	customer.Password = password
+	customer.UpdatedAt = s.timeProvider.GetTime()
+
+	// the sessions opened with the previous password are ended together with
+	// the change, only the refresh tokens issued after it are accepted
+	if err := s.customerRepository.ChangePassword(ctx, customer); err != nil {
+		return ProfileResponse{}, err
+	}
+
+	customer.Version++
+
+	return newProfileResponse(customer), nil
+}
+
+func newProfileResponse(customer entity.Customer) ProfileResponse {
+	if customer.IsAnonymous {
+		return ProfileResponse{
+			Id:          customer.Id,
+			IsAnonymous: true,
+			CreatedAt:   customer.CreatedAt,
+			UpdatedAt:   customer.UpdatedAt,
+			Version:     customer.Version,
+		}
+	}
+
+	return ProfileResponse{
+		Id:           customer.Id,
+		DocumentId:   mask.Document(customer.DocumentId),
+		DocumentType: customer.DocumentType.String(),
+		IsAnonymous:  false,
+		CreatedAt:    customer.CreatedAt,
+		UpdatedAt:    customer.UpdatedAt,
+		Version:      customer.Version,
+	}
+}
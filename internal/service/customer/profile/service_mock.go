@@ -0,0 +1,84 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package profile
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, customerId
+func (_m *MockService) Get(ctx context.Context, customerId string) (ProfileResponse, error) {
+	ret := _m.Called(ctx, customerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 ProfileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ProfileResponse, error)); ok {
+		return rf(ctx, customerId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) ProfileResponse); ok {
+		r0 = rf(ctx, customerId)
+	} else {
+		r0 = ret.Get(0).(ProfileResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, customerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, customerId, request
+func (_m *MockService) Update(ctx context.Context, customerId string, request UpdateProfileRequest) (ProfileResponse, error) {
+	ret := _m.Called(ctx, customerId, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 ProfileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, UpdateProfileRequest) (ProfileResponse, error)); ok {
+		return rf(ctx, customerId, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, UpdateProfileRequest) ProfileResponse); ok {
+		r0 = rf(ctx, customerId, request)
+	} else {
+		r0 = ret.Get(0).(ProfileResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, UpdateProfileRequest) error); ok {
+		r1 = rf(ctx, customerId, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
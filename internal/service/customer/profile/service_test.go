@@ -0,0 +1,337 @@
+package profile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/credentials"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/profile"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
+func TestService_Get(t *testing.T) {
+	t.Run("Should return the profile with the document masked", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{
+				Id:           "customer-1",
+				DocumentId:   "52998224725",
+				DocumentType: document.TypeCPF,
+				Password:     "secret",
+				Version:      3,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}, nil)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		res, err := service.Get(ctx, "customer-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, profile.ProfileResponse{
+			Id:           "customer-1",
+			DocumentId:   "***.982.247-**",
+			DocumentType: "cpf",
+			IsAnonymous:  false,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			Version:      3,
+		}, res)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return a reduced profile for anonymous customers", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{
+				Id:          "customer-1",
+				DocumentId:  "12345678901",
+				IsAnonymous: true,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}, nil)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		res, err := service.Get(ctx, "customer-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, profile.ProfileResponse{
+			Id:          "customer-1",
+			IsAnonymous: true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}, res)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Get(ctx, "customer-1")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		customerRepository.AssertExpectations(t)
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	created := now.Add(-time.Hour)
+
+	stored := entity.Customer{
+		Id:           "customer-1",
+		DocumentId:   "52998224725",
+		DocumentType: document.TypeCPF,
+		Password:     "old-hashed-password",
+		Version:      2,
+		CreatedAt:    created,
+		UpdatedAt:    created,
+	}
+
+	request := profile.UpdateProfileRequest{
+		Version:         2,
+		CurrentPassword: "12345678",
+		Password:        "87654321",
+	}
+
+	t.Run("Should update the password and bump the version", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(stored, nil)
+
+		credentialsService.On("VerifyPassword", stored, "12345678").
+			Return(nil)
+
+		passwordProvider.On("Hash", "87654321").
+			Return("new-hashed-password", nil)
+
+		customerRepository.On("ChangePassword", ctx, mock.MatchedBy(func(customer entity.Customer) bool {
+			return customer.Id == "customer-1" &&
+				customer.Password == "new-hashed-password" &&
+				customer.Version == 2 &&
+				customer.UpdatedAt.Equal(now)
+		})).Return(nil)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		res, err := service.Update(ctx, "customer-1", request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, profile.ProfileResponse{
+			Id:           "customer-1",
+			DocumentId:   "***.982.247-**",
+			DocumentType: "cpf",
+			IsAnonymous:  false,
+			CreatedAt:    created,
+			UpdatedAt:    now,
+			Version:      3,
+		}, res)
+		passwordProvider.AssertExpectations(t)
+		credentialsService.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is not valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", profile.UpdateProfileRequest{
+			Version:         2,
+			CurrentPassword: "12345678",
+			Password:        "short",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the version does not match", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(stored, nil)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", profile.UpdateProfileRequest{
+			Version:         1,
+			CurrentPassword: "12345678",
+			Password:        "87654321",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerVersionMismatch)
+		credentialsService.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer is anonymous", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(entity.Customer{Id: "customer-1", IsAnonymous: true, Version: 2}, nil)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerIsAnonymous)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the current password is wrong", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(stored, nil)
+
+		credentialsService.On("VerifyPassword", stored, "12345678").
+			Return(custom_error.ErrInvalidCredentials)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrInvalidCredentials)
+		credentialsService.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer was changed concurrently", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(stored, nil)
+
+		credentialsService.On("VerifyPassword", stored, "12345678").
+			Return(nil)
+
+		passwordProvider.On("Hash", "87654321").
+			Return("new-hashed-password", nil)
+
+		customerRepository.On("ChangePassword", ctx, mock.Anything).
+			Return(custom_error.ErrCustomerVersionMismatch)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", request)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerVersionMismatch)
+		passwordProvider.AssertExpectations(t)
+		credentialsService.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the password cannot be hashed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		passwordProvider := provider.NewMockPasswordProvider(t)
+		credentialsService := credentials.NewMockService(t)
+		customerRepository := customer.NewMockRepository(t)
+
+		customerRepository.On("Get", ctx, "customer-1").
+			Return(stored, nil)
+
+		credentialsService.On("VerifyPassword", stored, "12345678").
+			Return(nil)
+
+		passwordProvider.On("Hash", "87654321").
+			Return("", assert.AnError)
+
+		service := profile.NewService(timeProvider, passwordProvider, credentialsService, customerRepository)
+
+		// Act
+		_, err := service.Update(ctx, "customer-1", request)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		passwordProvider.AssertExpectations(t)
+		customerRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,7 @@
+package reencryption
+
+import "context"
+
+type Service interface {
+	Reencrypt(ctx context.Context) error
+}
@@ -0,0 +1,69 @@
+package reencryption
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/worker"
+)
+
+type service struct {
+	config *environment.EncryptionConfig
+
+	deleteRequestRepository delete_request.Repository
+}
+
+func NewService(
+	config *environment.EncryptionConfig,
+	deleteRequestRepository delete_request.Repository,
+) Service {
+	return &service{
+		config:                  config,
+		deleteRequestRepository: deleteRequestRepository,
+	}
+}
+
+// Reencrypt moves, in batches, the deletion requests to the current key
+// version until none is left behind, so a previous master key can be retired
+// once a run completes after the rotation without skipped requests.
+func (s *service) Reencrypt(ctx context.Context) error {
+	reencrypted := 0
+	skipped := 0
+	afterId := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the remaining batches are picked up by the next run
+		if worker.Stopping(ctx) {
+			break
+		}
+
+		result, err := s.deleteRequestRepository.Reencrypt(ctx, afterId, s.config.ReencryptBatchSize)
+		if err != nil {
+			return err
+		}
+
+		reencrypted += result.Reencrypted
+		skipped += result.Skipped
+		afterId = result.LastId
+
+		if result.Read < s.config.ReencryptBatchSize {
+			break
+		}
+	}
+
+	if reencrypted > 0 {
+		logger.FromContext(ctx).InfoContext(ctx, "deletion requests re-encrypted", "reencrypted", reencrypted, "key_version", s.config.CurrentKeyVersion)
+	}
+
+	if skipped > 0 {
+		logger.FromContext(ctx).WarnContext(ctx, "deletion requests left behind the current key version", "skipped", skipped, "key_version", s.config.CurrentKeyVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package reencryption_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/delete_request"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/reencryption"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var config = &environment.EncryptionConfig{
+	CurrentKeyVersion:  2,
+	ReencryptBatchSize: 10,
+}
+
+func TestService_Reencrypt(t *testing.T) {
+	t.Run("Should re-encrypt the batches until none is left behind", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("Reencrypt", ctx, "", 10).
+			Return(delete_request.ReencryptResult{LastId: "id-10", Read: 10, Reencrypted: 10}, nil).
+			Once()
+
+		deleteRequestRepository.On("Reencrypt", ctx, "id-10", 10).
+			Return(delete_request.ReencryptResult{LastId: "id-20", Read: 10, Reencrypted: 9, Skipped: 1}, nil).
+			Once()
+
+		deleteRequestRepository.On("Reencrypt", ctx, "id-20", 10).
+			Return(delete_request.ReencryptResult{LastId: "id-23", Read: 3, Reencrypted: 3}, nil).
+			Once()
+
+		service := reencryption.NewService(config, deleteRequestRepository)
+
+		// Act
+		err := service.Reencrypt(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should leave between batches when the worker is stopping", func(t *testing.T) {
+		// Arrange
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		service := reencryption.NewService(config, deleteRequestRepository)
+
+		reencryptionWorker := worker.NewWorker("test", time.Hour, service.Reencrypt)
+
+		stopped := make(chan error)
+
+		deleteRequestRepository.On("Reencrypt", mock.Anything, "", 10).
+			Run(func(args mock.Arguments) {
+				go func() {
+					stopped <- reencryptionWorker.Stop(context.Background())
+				}()
+
+				assert.Eventually(t, func() bool {
+					return worker.Stopping(args.Get(0).(context.Context))
+				}, time.Second, 5*time.Millisecond)
+			}).
+			Return(delete_request.ReencryptResult{LastId: "id-10", Read: 10, Reencrypted: 10}, nil).
+			Once()
+
+		// Act
+		reencryptionWorker.Start(context.Background())
+
+		// Assert
+		assert.NoError(t, <-stopped)
+	})
+
+	t.Run("Should stop when there is nothing to re-encrypt", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("Reencrypt", ctx, "", 10).
+			Return(delete_request.ReencryptResult{}, nil).
+			Once()
+
+		service := reencryption.NewService(config, deleteRequestRepository)
+
+		// Act
+		err := service.Reencrypt(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error if a batch could not be re-encrypted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		deleteRequestRepository.On("Reencrypt", ctx, "", 10).
+			Return(delete_request.ReencryptResult{LastId: "id-10", Read: 10, Reencrypted: 10}, nil).
+			Once()
+
+		deleteRequestRepository.On("Reencrypt", ctx, "id-10", 10).
+			Return(delete_request.ReencryptResult{}, errors.New("something got wrong")).
+			Once()
+
+		service := reencryption.NewService(config, deleteRequestRepository)
+
+		// Act
+		err := service.Reencrypt(ctx)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error if the context is cancelled", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		deleteRequestRepository := delete_request.NewMockRepository(t)
+
+		service := reencryption.NewService(config, deleteRequestRepository)
+
+		// Act
+		err := service.Reencrypt(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,69 @@
+package registration
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/validation"
+)
+
+// RegisterCustomerRequest registers a customer identified by its CPF, or
+// CNPJ, and password, or an anonymous customer that has neither of them.
+type RegisterCustomerRequest struct {
+	DocumentId  string `json:"document_id" validate:"omitempty,document"`
+	Password    string `json:"password" validate:"omitempty,min=8,maxbytes=72"`
+	IsAnonymous bool   `json:"is_anonymous"`
+}
+
+func (r *RegisterCustomerRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	if r.IsAnonymous {
+		if r.DocumentId != "" || r.Password != "" {
+			return custom_error.ErrRequestNotValid
+		}
+
+		return nil
+	}
+
+	if r.DocumentId == "" || r.Password == "" {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type IdentifyCustomerRequest struct {
+	DocumentId string `json:"document_id" validate:"required,document"`
+}
+
+func (r *IdentifyCustomerRequest) Validate() error {
+	if err := validation.Default().Struct(r); err != nil {
+		return custom_error.ErrRequestNotValid
+	}
+
+	return nil
+}
+
+type CustomerResponse struct {
+	Id          string    `json:"id"`
+	IsAnonymous bool      `json:"is_anonymous"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewCustomerResponse(customer entity.Customer) CustomerResponse {
+	return CustomerResponse{
+		Id:          customer.Id,
+		IsAnonymous: customer.IsAnonymous,
+		CreatedAt:   customer.CreatedAt,
+	}
+}
+
+type Service interface {
+	Register(ctx context.Context, request RegisterCustomerRequest) (CustomerResponse, error)
+	Identify(ctx context.Context, request IdentifyCustomerRequest) (CustomerResponse, error)
+}
@@ -0,0 +1,84 @@
+package registration
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+)
+
+type service struct {
+	timeProvider     provider.TimeProvider
+	passwordProvider provider.PasswordProvider
+
+	customerRepository customer.Repository
+}
+
+func NewService(
+	timeProvider provider.TimeProvider,
+	passwordProvider provider.PasswordProvider,
+	customerRepository customer.Repository,
+) Service {
+	return &service{
+		timeProvider:       timeProvider,
+		passwordProvider:   passwordProvider,
+		customerRepository: customerRepository,
+	}
+}
+
+func (s *service) Register(ctx context.Context, request RegisterCustomerRequest) (CustomerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return CustomerResponse{}, err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	customer := entity.NewAnonymousCustomer(now)
+
+	if !request.IsAnonymous {
+		documentType, documentId, err := document.Parse(request.DocumentId)
+		if err != nil {
+			return CustomerResponse{}, custom_error.ErrRequestNotValid
+		}
+
+		// checked beforehand to give a proper error on the common case, the
+		// unique index still guards against concurrent registrations
+		_, err = s.customerRepository.GetByDocument(ctx, documentId)
+		if err == nil {
+			return CustomerResponse{}, custom_error.ErrCustomerAlreadyExists
+		}
+
+		if err != custom_error.ErrCustomerNotFound {
+			return CustomerResponse{}, err
+		}
+
+		password, err := s.passwordProvider.Hash(request.Password)
+		if err != nil {
+			return CustomerResponse{}, err
+		}
+
+		customer = entity.NewCustomer(documentId, documentType, password, now)
+	}
+
+	if err := s.customerRepository.Create(ctx, customer); err != nil {
+		return CustomerResponse{}, err
+	}
+
+	return NewCustomerResponse(customer), nil
+}
+
+func (s *service) Identify(ctx context.Context, request IdentifyCustomerRequest) (CustomerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return CustomerResponse{}, err
+	}
+
+	customer, err := s.customerRepository.GetByDocument(ctx, document.Normalize(request.DocumentId))
+	if err != nil {
+		return CustomerResponse{}, err
+	}
+
+	return NewCustomerResponse(customer), nil
+}
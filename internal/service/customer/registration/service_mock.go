@@ -0,0 +1,84 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package registration
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Identify provides a mock function with given fields: ctx, request
+func (_m *MockService) Identify(ctx context.Context, request IdentifyCustomerRequest) (CustomerResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Identify")
+	}
+
+	var r0 CustomerResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, IdentifyCustomerRequest) (CustomerResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, IdentifyCustomerRequest) CustomerResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(CustomerResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, IdentifyCustomerRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: ctx, request
+func (_m *MockService) Register(ctx context.Context, request RegisterCustomerRequest) (CustomerResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 CustomerResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, RegisterCustomerRequest) (CustomerResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, RegisterCustomerRequest) CustomerResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(CustomerResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, RegisterCustomerRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,297 @@
+package registration_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/customer"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/customer/registration"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+var timeProvider = time_provider.NewTimeProvider(func() time.Time {
+	return now
+})
+
+func TestService_Register(t *testing.T) {
+	t.Run("Should register a customer with its document", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		passwordProvider.On("Hash", "12345678").
+			Return("hashed-password", nil)
+
+		customerRepository.On("Create", ctx, mock.MatchedBy(func(customer entity.Customer) bool {
+			return customer.Id != "" &&
+				customer.DocumentId == "52998224725" &&
+				customer.DocumentType == document.TypeCPF &&
+				customer.Password == "hashed-password" &&
+				!customer.IsAnonymous &&
+				customer.CreatedAt.Equal(now)
+		})).
+			Return(nil)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			DocumentId: "529.982.247-25",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Id)
+		assert.False(t, res.IsAnonymous)
+		assert.Equal(t, now, res.CreatedAt)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should register an anonymous customer", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("Create", ctx, mock.MatchedBy(func(customer entity.Customer) bool {
+			return customer.IsAnonymous && customer.DocumentId == "" && customer.Password == ""
+		})).
+			Return(nil)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			IsAnonymous: true,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, res.IsAnonymous)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the document is already registered", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1"}, nil)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerAlreadyExists)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the document is registered concurrently", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		passwordProvider.On("Hash", "12345678").
+			Return("hashed-password", nil)
+
+		customerRepository.On("Create", ctx, mock.Anything).
+			Return(custom_error.ErrCustomerAlreadyExists)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerAlreadyExists)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to hash the password", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		passwordProvider.On("Hash", "12345678").
+			Return("", errors.New("error"))
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		customerRepository.AssertExpectations(t)
+		passwordProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to look up the document", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, errors.New("error"))
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Register(ctx, registration.RegisterCustomerRequest{
+			DocumentId: "52998224725",
+			Password:   "12345678",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the request is not valid", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			request registration.RegisterCustomerRequest
+		}{
+			{"missing document", registration.RegisterCustomerRequest{Password: "12345678"}},
+			{"missing password", registration.RegisterCustomerRequest{DocumentId: "52998224725"}},
+			{"short password", registration.RegisterCustomerRequest{DocumentId: "52998224725", Password: "123"}},
+			{"password longer than 72 bytes", registration.RegisterCustomerRequest{DocumentId: "52998224725", Password: strings.Repeat("ç", 40)}},
+			{"document with letters", registration.RegisterCustomerRequest{DocumentId: "5299822472a", Password: "12345678"}},
+			{"document with wrong check digit", registration.RegisterCustomerRequest{DocumentId: "52998224724", Password: "12345678"}},
+			{"anonymous with document", registration.RegisterCustomerRequest{DocumentId: "52998224725", IsAnonymous: true}},
+			{"anonymous with password", registration.RegisterCustomerRequest{Password: "12345678", IsAnonymous: true}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				ctx := context.Background()
+
+				customerRepository := customer.NewMockRepository(t)
+				passwordProvider := provider.NewMockPasswordProvider(t)
+
+				service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+				// Act
+				_, err := service.Register(ctx, tc.request)
+
+				// Assert
+				assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+				customerRepository.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestService_Identify(t *testing.T) {
+	t.Run("Should identify the customer by its document", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{Id: "customer-1", DocumentId: "52998224725", DocumentType: document.TypeCPF, CreatedAt: now}, nil)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		res, err := service.Identify(ctx, registration.IdentifyCustomerRequest{
+			DocumentId: "529.982.247-25",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, registration.CustomerResponse{
+			Id:        "customer-1",
+			CreatedAt: now,
+		}, res)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the customer is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		customerRepository.On("GetByDocument", ctx, "52998224725").
+			Return(entity.Customer{}, custom_error.ErrCustomerNotFound)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Identify(ctx, registration.IdentifyCustomerRequest{
+			DocumentId: "52998224725",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrCustomerNotFound)
+		customerRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when the document is not valid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		customerRepository := customer.NewMockRepository(t)
+		passwordProvider := provider.NewMockPasswordProvider(t)
+
+		service := registration.NewService(timeProvider, passwordProvider, customerRepository)
+
+		// Act
+		_, err := service.Identify(ctx, registration.IdentifyCustomerRequest{
+			DocumentId: "123",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		customerRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,8 @@
+package relay
+
+import "context"
+
+type Service interface {
+	Relay(ctx context.Context) error
+	Cleanup(ctx context.Context) error
+}
@@ -0,0 +1,118 @@
+package relay
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/backoff"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/logger"
+)
+
+type service struct {
+	config *environment.OutboxConfig
+
+	timeProvider provider.TimeProvider
+
+	outboxRepository outbox.Repository
+
+	publisher cloud.TopicService
+}
+
+func NewService(
+	config *environment.OutboxConfig,
+	timeProvider provider.TimeProvider,
+	outboxRepository outbox.Repository,
+	publisher cloud.TopicService,
+) Service {
+	return &service{
+		config:           config,
+		timeProvider:     timeProvider,
+		outboxRepository: outboxRepository,
+		publisher:        publisher,
+	}
+}
+
+// Relay publishes the pending messages of the outbox. A message may be
+// published more than once if it cannot be flagged as sent, so the
+// subscribers must be idempotent.
+func (s *service) Relay(ctx context.Context) error {
+	now := s.timeProvider.GetTime()
+
+	messages, err := s.outboxRepository.ClaimPending(ctx,
+		now,
+		now.Add(s.config.LockDuration),
+		s.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := s.publish(ctx, message); err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "error relaying outbox message", "message_id", message.Id, "attempts", message.Attempts, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) publish(ctx context.Context, message entity.OutboxMessage) error {
+	// the outcome is only saved while the message is still claimed by this
+	// relay, the lock is changed when the message is marked
+	claimedUntil := message.LockedUntil
+
+	_, err := s.publisher.PublishMessage(ctx, outboxEvent{message})
+
+	now := s.timeProvider.GetTime()
+
+	if err != nil {
+		nextAttemptAt := now.Add(backoff.Exponential(s.config.RetryBackoff, s.config.MaxRetryBackoff, message.Attempts))
+
+		message.MarkAsFailed(err, now, nextAttemptAt, s.config.MaxAttempts)
+
+		if err := s.outboxRepository.Update(ctx, message, claimedUntil); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	message.MarkAsSent(now)
+
+	return s.outboxRepository.Update(ctx, message, claimedUntil)
+}
+
+func (s *service) Cleanup(ctx context.Context) error {
+	deleted, err := s.outboxRepository.DeleteSentBefore(ctx, s.timeProvider.GetTime().Add(-s.config.Retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		logger.FromContext(ctx).InfoContext(ctx, "outbox messages cleaned up", "deleted", deleted)
+	}
+
+	return nil
+}
+
+// outboxEvent publishes the stored payload as is, since it is already the
+// serialized event, along with the event attributes.
+type outboxEvent struct {
+	message entity.OutboxMessage
+}
+
+func (e outboxEvent) MarshalJSON() ([]byte, error) {
+	return []byte(e.message.Payload), nil
+}
+
+func (e outboxEvent) GetMessageAttributes() map[string]string {
+	return map[string]string{
+		"event_type":     string(e.message.EventType),
+		"event_version":  strconv.Itoa(e.message.EventVersion),
+		"correlation_id": e.message.CorrelationId,
+	}
+}
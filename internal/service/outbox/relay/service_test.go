@@ -0,0 +1,284 @@
+package relay_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/repository/outbox"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/service/outbox/relay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var config = &environment.OutboxConfig{
+	BatchSize:       50,
+	MaxAttempts:     3,
+	LockDuration:    time.Minute,
+	RetryBackoff:    10 * time.Second,
+	MaxRetryBackoff: 10 * time.Minute,
+	Retention:       24 * time.Hour,
+}
+
+func TestService_Relay(t *testing.T) {
+	t.Run("Should publish the pending messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("ClaimPending", ctx, now, now.Add(time.Minute), 50).
+			Return([]entity.OutboxMessage{
+				{
+					Id:            "id-1",
+					EventType:     entity.EventTypeCustomerDeleted,
+					EventVersion:  1,
+					CorrelationId: "correlation-1",
+					Payload:       `{"type":"CustomerDeleted"}`,
+					Status:        entity.OutboxMessageStatusPending,
+					LockedUntil:   now.Add(time.Minute),
+				},
+			}, nil)
+
+		publisher.On("PublishMessage", ctx, mock.MatchedBy(func(message interface{}) bool {
+			body, err := json.Marshal(message)
+			if err != nil || string(body) != `{"type":"CustomerDeleted"}` {
+				return false
+			}
+
+			attributes := message.(cloud.MessageWithAttributes).GetMessageAttributes()
+
+			return attributes["event_type"] == "CustomerDeleted" &&
+				attributes["event_version"] == "1" &&
+				attributes["correlation_id"] == "correlation-1"
+		})).
+			Return(aws.String("message-id"), nil)
+
+		outboxRepository.On("Update", ctx, mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.Status == entity.OutboxMessageStatusSent &&
+				message.SentAt.Equal(now)
+		}), now.Add(time.Minute)).
+			Return(nil)
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Relay(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("Should schedule a retry when the message could not be published", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 50).
+			Return([]entity.OutboxMessage{
+				{Id: "id-1", Payload: "{}", Attempts: 1, Status: entity.OutboxMessageStatusPending},
+			}, nil)
+
+		publisher.On("PublishMessage", ctx, mock.Anything).
+			Return(nil, errors.New("error"))
+
+		outboxRepository.On("Update", ctx, mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.Status == entity.OutboxMessageStatusPending &&
+				message.Attempts == 2 &&
+				message.LastError == "error" &&
+				message.NextAttemptAt.Equal(now.Add(20*time.Second))
+		}), mock.Anything).
+			Return(nil)
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Relay(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("Should flag the message as failed when the attempts are exhausted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 50).
+			Return([]entity.OutboxMessage{
+				{Id: "id-1", Payload: "{}", Attempts: 2, Status: entity.OutboxMessageStatusPending},
+			}, nil)
+
+		publisher.On("PublishMessage", ctx, mock.Anything).
+			Return(nil, errors.New("error"))
+
+		outboxRepository.On("Update", ctx, mock.MatchedBy(func(message entity.OutboxMessage) bool {
+			return message.Status == entity.OutboxMessageStatusFailed && message.Attempts == 3
+		}), mock.Anything).
+			Return(nil)
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Relay(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("Should keep going when a message could not be updated", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 50).
+			Return([]entity.OutboxMessage{
+				{Id: "id-1", Payload: "{}"},
+				{Id: "id-2", Payload: "{}"},
+			}, nil)
+
+		publisher.On("PublishMessage", ctx, mock.Anything).
+			Return(aws.String("message-id"), nil).
+			Times(2)
+
+		outboxRepository.On("Update", ctx, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Times(2)
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Relay(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when try to claim the pending messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("ClaimPending", ctx, mock.Anything, mock.Anything, 50).
+			Return(nil, errors.New("error"))
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Relay(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+}
+
+func TestService_Cleanup(t *testing.T) {
+	t.Run("Should delete the messages sent before the retention", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("DeleteSentBefore", ctx, now.Add(-24*time.Hour)).
+			Return(int64(10), nil)
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Cleanup(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("Should return an error when try to delete the messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider.NewMockTimeProvider(t)
+		outboxRepository := outbox.NewMockRepository(t)
+		publisher := mocks.NewMockTopicService(t)
+
+		timeProvider.On("GetTime").
+			Return(now)
+
+		outboxRepository.On("DeleteSentBefore", ctx, mock.Anything).
+			Return(int64(0), errors.New("error"))
+
+		service := relay.NewService(config, timeProvider, outboxRepository, publisher)
+
+		// Act
+		err := service.Cleanup(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		timeProvider.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+}
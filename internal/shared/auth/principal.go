@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrPrincipalNotFound = errors.New("no authenticated principal in the context")
+)
+
+type principalKey struct{}
+
+// Principal is who made the request, as stated by the verified token.
+type Principal struct {
+	Subject string
+	Roles   []string
+	TokenId string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (Principal, error) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok {
+		return Principal{}, ErrPrincipalNotFound
+	}
+
+	return principal, nil
+}
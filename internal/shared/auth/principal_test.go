@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("Should return the principal of the context", func(t *testing.T) {
+		// Arrange
+		ctx := WithPrincipal(context.Background(), Principal{
+			Subject: "user-id",
+			Roles:   []string{"admin"},
+			TokenId: "token-id",
+		})
+
+		// Act
+		res, err := FromContext(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-id", res.Subject)
+		assert.Equal(t, "token-id", res.TokenId)
+		assert.True(t, res.HasRole("admin"))
+		assert.False(t, res.HasRole("customer"))
+	})
+
+	t.Run("Should return an error when there is no principal", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		res, err := FromContext(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPrincipalNotFound)
+		assert.Empty(t, res)
+	})
+}
@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Exponential doubles the base duration for each attempt already made,
+// without going over the max duration.
+func Exponential(base time.Duration, max time.Duration, attempts int) time.Duration {
+	backoff := base
+
+	for i := 0; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, max)
+}
@@ -0,0 +1,37 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponential(t *testing.T) {
+	t.Run("Should return the base duration on the first attempt", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Exponential(time.Minute, time.Hour, 0)
+
+		// Assert
+		assert.Equal(t, time.Minute, res)
+	})
+
+	t.Run("Should double the duration for each attempt", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Exponential(time.Minute, time.Hour, 3)
+
+		// Assert
+		assert.Equal(t, 8*time.Minute, res)
+	})
+
+	t.Run("Should limit the duration", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Exponential(time.Minute, time.Hour, 100)
+
+		// Assert
+		assert.Equal(t, time.Hour, res)
+	})
+}
@@ -1,6 +1,13 @@
 package custom_error
 
-import "github.com/labstack/echo/v4"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const internalErrorDetails = "an internal error occurred, reach out to the support with the request id"
 
 type AppError struct {
 	Code    int    `json:"code"`
@@ -22,3 +29,22 @@ func NewHttpAppErrorFromBusinessError(err error) *echo.HTTPError {
 	buErr := err.(BusinessError)
 	return NewHttpAppError(buErr.Code(), buErr.Title(), err)
 }
+
+// HideInternalDetails replaces the details of the internal errors, they are
+// copied from errors that may carry personal data or internals of the
+// service. The logs keep the original error.
+func HideInternalDetails(err error) error {
+	var httpError *echo.HTTPError
+	if !errors.As(err, &httpError) || httpError.Code < http.StatusInternalServerError {
+		return err
+	}
+
+	appError, ok := httpError.Message.(AppError)
+	if !ok {
+		return err
+	}
+
+	appError.Details = internalErrorDetails
+
+	return echo.NewHTTPError(httpError.Code, appError)
+}
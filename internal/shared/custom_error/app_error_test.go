@@ -2,8 +2,10 @@ package custom_error
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +45,45 @@ func TestNewHttpAppErrorFromBusinessError(t *testing.T) {
 		}, err.Message)
 	})
 }
+
+func TestHideInternalDetails(t *testing.T) {
+	t.Run("Should hide the details of the internal errors", func(t *testing.T) {
+		// Arrange
+		err := NewHttpAppError(http.StatusInternalServerError, "internal error", errors.New("pq: invalid input value 12345678901"))
+
+		// Act
+		res := HideInternalDetails(err)
+
+		// Assert
+		httpError, ok := res.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, httpError.Code)
+		assert.Equal(t, AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal error",
+			Details: internalErrorDetails,
+		}, httpError.Message)
+	})
+
+	t.Run("Should keep the details of the client errors", func(t *testing.T) {
+		// Arrange
+		err := NewHttpAppErrorFromBusinessError(ErrCustomerNotFound)
+
+		// Act
+		res := HideInternalDetails(err)
+
+		// Assert
+		assert.Same(t, err, res)
+	})
+
+	t.Run("Should keep the errors that are not app errors", func(t *testing.T) {
+		// Arrange
+		err := errors.New("error")
+
+		// Act
+		res := HideInternalDetails(err)
+
+		// Assert
+		assert.Equal(t, err, res)
+	})
+}
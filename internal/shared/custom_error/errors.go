@@ -5,8 +5,23 @@ import "net/http"
 var (
 	ErrRequestNotValid BusinessError = New(http.StatusUnprocessableEntity, "validation error", "request not valid, please check the fields")
 
-	ErrCustomerNotFound BusinessError = New(http.StatusNotFound, "customer not found", "unable to find customer with the given id")
+	ErrCustomerNotFound      BusinessError = New(http.StatusNotFound, "customer not found", "unable to find customer with the given id")
+	ErrCustomerAlreadyExists BusinessError = New(http.StatusConflict, "customer already exists", "a customer with the given document already exists")
+	ErrInvalidCredentials    BusinessError = New(http.StatusUnauthorized, "invalid credentials", "the document or the password is not valid")
 
-	ErrDeletionRequestAlreadyCreated BusinessError = New(http.StatusBadRequest, "deletion request already created", "deletion request already created for the given customer id")
-	ErrDeletionRequestNotFound       BusinessError = New(http.StatusNotFound, "deletion request not found", "unable to find deletion request with the given customer id")
+	ErrCustomerVersionMismatch BusinessError = New(http.StatusPreconditionFailed, "precondition failed", "the customer was changed by another request, get it again and retry")
+	ErrCustomerVersionRequired BusinessError = New(http.StatusPreconditionRequired, "precondition required", "the If-Match header with the ETag of the customer is required")
+	ErrCustomerIsAnonymous     BusinessError = New(http.StatusConflict, "customer is anonymous", "anonymous customers have no profile to update")
+
+	ErrDeletionRequestAlreadyCreated    BusinessError = New(http.StatusBadRequest, "deletion request already created", "deletion request already created for the given customer id")
+	ErrDeletionRequestNotFound          BusinessError = New(http.StatusNotFound, "deletion request not found", "unable to find deletion request with the given customer id")
+	ErrDeletionRequestCannotBeCancelled BusinessError = New(http.StatusConflict, "deletion request cannot be cancelled", "only pending deletion requests can be cancelled")
+	ErrDeletionRequestCannotBeExecuted  BusinessError = New(http.StatusConflict, "deletion request cannot be executed", "only pending or failed deletion requests can be executed")
+	ErrDeletionRequestCannotBeRejected  BusinessError = New(http.StatusConflict, "deletion request cannot be rejected", "only pending deletion requests can be rejected")
+	ErrDeletionRequestLockLost          BusinessError = New(http.StatusConflict, "deletion request lock lost", "the deletion request was claimed by another execution")
+
+	ErrRefreshTokenNotFound BusinessError = New(http.StatusNotFound, "refresh token not found", "unable to find an active refresh token with the given id")
+	ErrInvalidRefreshToken  BusinessError = New(http.StatusUnauthorized, "invalid refresh token", "the refresh token is not valid, expired or was revoked")
+
+	ErrOutboxMessageLockLost BusinessError = New(http.StatusConflict, "outbox message lock lost", "the outbox message was claimed by another relay")
 )
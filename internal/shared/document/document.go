@@ -0,0 +1,192 @@
+package document
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Type is persisted as the document_type column of the customers.
+type Type int
+
+const (
+	TypeUnknown Type = iota
+	TypeCPF
+	TypeCNPJ
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+var ErrInvalidDocument = errors.New("the document is not a valid cpf or cnpj")
+
+var (
+	cnpjFirstWeights  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeCPF:
+		return "cpf"
+	case TypeCNPJ:
+		return "cnpj"
+	default:
+		return ""
+	}
+}
+
+// Normalize strips the punctuation of the document, as in 123.456.789-09,
+// keeping only its digits.
+func Normalize(value string) string {
+	var builder strings.Builder
+
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+// Parse validates the document and returns its type along with the
+// normalized value.
+func Parse(value string) (Type, string, error) {
+	normalized := Normalize(value)
+
+	if !hasOnlyDocumentChars(value) {
+		return TypeUnknown, "", ErrInvalidDocument
+	}
+
+	switch {
+	case isValidCPF(normalized):
+		return TypeCPF, normalized, nil
+	case isValidCNPJ(normalized):
+		return TypeCNPJ, normalized, nil
+	default:
+		return TypeUnknown, "", ErrInvalidDocument
+	}
+}
+
+func IsValid(value string) bool {
+	_, _, err := Parse(value)
+	return err == nil
+}
+
+func IsValidCPF(value string) bool {
+	return hasOnlyDocumentChars(value) && isValidCPF(Normalize(value))
+}
+
+func IsValidCNPJ(value string) bool {
+	return hasOnlyDocumentChars(value) && isValidCNPJ(Normalize(value))
+}
+
+// Format returns the document with its punctuation, values that are neither
+// a cpf nor a cnpj are returned as they are.
+func Format(value string) string {
+	digits := Normalize(value)
+
+	switch len(digits) {
+	case cpfLength:
+		return digits[0:3] + "." + digits[3:6] + "." + digits[6:9] + "-" + digits[9:11]
+	case cnpjLength:
+		return digits[0:2] + "." + digits[2:5] + "." + digits[5:8] + "/" + digits[8:12] + "-" + digits[12:14]
+	default:
+		return value
+	}
+}
+
+// RegisterValidations adds the document, cpf and cnpj tags to the validator.
+func RegisterValidations(validate *validator.Validate) error {
+	validations := map[string]func(string) bool{
+		"document": IsValid,
+		"cpf":      IsValidCPF,
+		"cnpj":     IsValidCNPJ,
+	}
+
+	for tag, isValid := range validations {
+		if err := validate.RegisterValidation(tag, fieldValidation(isValid)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func fieldValidation(isValid func(string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return isValid(fl.Field().String())
+	}
+}
+
+func isValidCPF(digits string) bool {
+	if len(digits) != cpfLength || hasAllDigitsEqual(digits) {
+		return false
+	}
+
+	for length := 9; length <= 10; length++ {
+		sum := 0
+		for i := 0; i < length; i++ {
+			sum += digitAt(digits, i) * (length + 1 - i)
+		}
+
+		checkDigit := sum * 10 % 11
+		if checkDigit == 10 {
+			checkDigit = 0
+		}
+
+		if checkDigit != digitAt(digits, length) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidCNPJ(digits string) bool {
+	if len(digits) != cnpjLength || hasAllDigitsEqual(digits) {
+		return false
+	}
+
+	for _, weights := range [][]int{cnpjFirstWeights, cnpjSecondWeights} {
+		sum := 0
+		for i, weight := range weights {
+			sum += digitAt(digits, i) * weight
+		}
+
+		checkDigit := 0
+		if rest := sum % 11; rest >= 2 {
+			checkDigit = 11 - rest
+		}
+
+		if checkDigit != digitAt(digits, len(weights)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasOnlyDocumentChars rejects values with letters or any other character
+// that is not part of the punctuation of a document.
+func hasOnlyDocumentChars(value string) bool {
+	for _, r := range value {
+		if (r < '0' || r > '9') && !strings.ContainsRune(".-/ ", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasAllDigitsEqual(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
+
+func digitAt(digits string, i int) int {
+	return int(digits[i] - '0')
+}
@@ -0,0 +1,136 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Should parse a cpf", func(t *testing.T) {
+		// Arrange
+		// Act
+		documentType, value, err := Parse("529.982.247-25")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, TypeCPF, documentType)
+		assert.Equal(t, "52998224725", value)
+	})
+
+	t.Run("Should parse a cnpj", func(t *testing.T) {
+		// Arrange
+		// Act
+		documentType, value, err := Parse("11.222.333/0001-81")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, TypeCNPJ, documentType)
+		assert.Equal(t, "11222333000181", value)
+	})
+
+	t.Run("Should return error when the document is not valid", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			value string
+		}{
+			{"empty", ""},
+			{"wrong cpf check digit", "52998224724"},
+			{"wrong cnpj check digit", "11222333000182"},
+			{"repeated digits", "11111111111"},
+			{"wrong length", "5299822472"},
+			{"letters", "529a982b247c25"},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				// Act
+				documentType, value, err := Parse(tc.value)
+
+				// Assert
+				assert.ErrorIs(t, err, ErrInvalidDocument)
+				assert.Equal(t, TypeUnknown, documentType)
+				assert.Empty(t, value)
+			})
+		}
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	t.Run("Should check the cpf", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, IsValidCPF("52998224725"))
+		assert.False(t, IsValidCPF("11222333000181"))
+	})
+
+	t.Run("Should check the cnpj", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, IsValidCNPJ("11222333000181"))
+		assert.False(t, IsValidCNPJ("52998224725"))
+	})
+
+	t.Run("Should accept both cpf and cnpj", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, IsValid("52998224725"))
+		assert.True(t, IsValid("11222333000181"))
+		assert.False(t, IsValid("12345678901"))
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("Should strip the punctuation", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Normalize(" 11.222.333/0001-81 ")
+
+		// Assert
+		assert.Equal(t, "11222333000181", res)
+	})
+}
+
+func TestFormat(t *testing.T) {
+	t.Run("Should format a cpf", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Format("52998224725")
+
+		// Assert
+		assert.Equal(t, "529.982.247-25", res)
+	})
+
+	t.Run("Should format a cnpj", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Format("11222333000181")
+
+		// Assert
+		assert.Equal(t, "11.222.333/0001-81", res)
+	})
+
+	t.Run("Should keep other values as they are", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Format("123")
+
+		// Assert
+		assert.Equal(t, "123", res)
+	})
+}
+
+func TestType_String(t *testing.T) {
+	t.Run("Should return the name of the type", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.Equal(t, "cpf", TypeCPF.String())
+		assert.Equal(t, "cnpj", TypeCNPJ.String())
+		assert.Equal(t, "", TypeUnknown.String())
+	})
+}
@@ -0,0 +1,101 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	t.Run("Should decrypt the encrypted value", func(t *testing.T) {
+		// Arrange
+		key, err := NewKey()
+		assert.NoError(t, err)
+
+		// Act
+		ciphertext, err := Encrypt(key, []byte("John Doe"), []byte("id"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotContains(t, string(ciphertext), "John Doe")
+
+		plaintext, err := Decrypt(key, ciphertext, []byte("id"))
+		assert.NoError(t, err)
+		assert.Equal(t, "John Doe", string(plaintext))
+	})
+
+	t.Run("Should use a different nonce on every encryption", func(t *testing.T) {
+		// Arrange
+		key, err := NewKey()
+		assert.NoError(t, err)
+
+		// Act
+		first, err := Encrypt(key, []byte("John Doe"), nil)
+		assert.NoError(t, err)
+
+		second, err := Encrypt(key, []byte("John Doe"), nil)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Should return error if the key has an invalid size", func(t *testing.T) {
+		// Act
+		res, err := Encrypt([]byte("short"), []byte("John Doe"), nil)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidKeySize)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDecrypt(t *testing.T) {
+	t.Run("Should return error if the additional data does not match", func(t *testing.T) {
+		// Arrange
+		key, err := NewKey()
+		assert.NoError(t, err)
+
+		ciphertext, err := Encrypt(key, []byte("John Doe"), []byte("id-1"))
+		assert.NoError(t, err)
+
+		// Act
+		res, err := Decrypt(key, ciphertext, []byte("id-2"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the key does not match", func(t *testing.T) {
+		// Arrange
+		key, err := NewKey()
+		assert.NoError(t, err)
+
+		otherKey, err := NewKey()
+		assert.NoError(t, err)
+
+		ciphertext, err := Encrypt(key, []byte("John Doe"), nil)
+		assert.NoError(t, err)
+
+		// Act
+		res, err := Decrypt(otherKey, ciphertext, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the ciphertext is too short", func(t *testing.T) {
+		// Arrange
+		key, err := NewKey()
+		assert.NoError(t, err)
+
+		// Act
+		res, err := Decrypt(key, []byte("short"), nil)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+}
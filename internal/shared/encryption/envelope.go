@@ -0,0 +1,103 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+)
+
+// PlaintextVersion is the key version of the values stored before the
+// encryption, they are read as they are until re-encrypted.
+const PlaintextVersion = 0
+
+// Sealed holds the encrypted values of a record together with the wrapped
+// data key that encrypted them, both base64 encoded to be stored as text.
+type Sealed struct {
+	Values     []string
+	DataKey    string
+	KeyVersion int
+}
+
+// Envelope encrypts every record with a data key of its own, wrapped by the
+// master key of the key provider. The previous master keys are kept after a
+// rotation to read the records not yet sealed again with the current one.
+type Envelope struct {
+	keyProvider provider.KeyProvider
+}
+
+func NewEnvelope(keyProvider provider.KeyProvider) *Envelope {
+	return &Envelope{
+		keyProvider: keyProvider,
+	}
+}
+
+func (e *Envelope) CurrentVersion() int {
+	return e.keyProvider.CurrentVersion()
+}
+
+// Seal encrypts the values with a new data key. Each value is bound to the
+// record and to its position, so it cannot be copied to another record or
+// swapped with another value of the same record.
+func (e *Envelope) Seal(ctx context.Context, recordId string, values ...string) (Sealed, error) {
+	dataKey, err := e.keyProvider.GenerateDataKey(ctx)
+	if err != nil {
+		return Sealed{}, err
+	}
+
+	sealed := Sealed{
+		Values:     make([]string, 0, len(values)),
+		DataKey:    base64.StdEncoding.EncodeToString(dataKey.Wrapped),
+		KeyVersion: dataKey.Version,
+	}
+
+	for i, value := range values {
+		ciphertext, err := Encrypt(dataKey.Plaintext, []byte(value), additionalData(recordId, i))
+		if err != nil {
+			return Sealed{}, err
+		}
+
+		sealed.Values = append(sealed.Values, base64.StdEncoding.EncodeToString(ciphertext))
+	}
+
+	return sealed, nil
+}
+
+func (e *Envelope) Open(ctx context.Context, recordId string, sealed Sealed) ([]string, error) {
+	if sealed.KeyVersion == PlaintextVersion {
+		return sealed.Values, nil
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(sealed.DataKey)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	dataKey, err := e.keyProvider.DecryptDataKey(ctx, wrapped, sealed.KeyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(sealed.Values))
+
+	for i, value := range sealed.Values {
+		ciphertext, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, ErrInvalidCiphertext
+		}
+
+		plaintext, err := Decrypt(dataKey, ciphertext, additionalData(recordId, i))
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, string(plaintext))
+	}
+
+	return values, nil
+}
+
+func additionalData(recordId string, position int) []byte {
+	return []byte(fmt.Sprintf("%s:%d", recordId, position))
+}
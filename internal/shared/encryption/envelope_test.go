@@ -0,0 +1,188 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope_Seal(t *testing.T) {
+	t.Run("Should seal the values with a new data key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		dataKey, err := NewKey()
+		assert.NoError(t, err)
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		keyProvider.On("GenerateDataKey", ctx).
+			Return(provider.DataKey{Plaintext: dataKey, Wrapped: []byte("wrapped"), Version: 2}, nil).
+			Once()
+
+		keyProvider.On("DecryptDataKey", ctx, []byte("wrapped"), 2).
+			Return(dataKey, nil).
+			Once()
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		res, err := envelope.Seal(ctx, "id", "John Doe", "Street 1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("wrapped")), res.DataKey)
+		assert.Equal(t, 2, res.KeyVersion)
+		assert.Len(t, res.Values, 2)
+		assert.NotContains(t, res.Values, "John Doe")
+
+		values, err := envelope.Open(ctx, "id", res)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"John Doe", "Street 1"}, values)
+	})
+
+	t.Run("Should return error if the data key could not be generated", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		keyProvider.On("GenerateDataKey", ctx).
+			Return(provider.DataKey{}, errors.New("something got wrong")).
+			Once()
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		_, err := envelope.Seal(ctx, "id", "John Doe")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestEnvelope_Open(t *testing.T) {
+	t.Run("Should read the plaintext values as they are", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		res, err := envelope.Open(ctx, "id", Sealed{
+			Values:     []string{"John Doe", "Street 1"},
+			KeyVersion: PlaintextVersion,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"John Doe", "Street 1"}, res)
+	})
+
+	t.Run("Should return error if the values were sealed for another record", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		dataKey, err := NewKey()
+		assert.NoError(t, err)
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		keyProvider.On("GenerateDataKey", ctx).
+			Return(provider.DataKey{Plaintext: dataKey, Wrapped: []byte("wrapped"), Version: 1}, nil).
+			Once()
+
+		keyProvider.On("DecryptDataKey", ctx, []byte("wrapped"), 1).
+			Return(dataKey, nil).
+			Once()
+
+		envelope := NewEnvelope(keyProvider)
+
+		sealed, err := envelope.Seal(ctx, "id-1", "John Doe")
+		assert.NoError(t, err)
+
+		// Act
+		res, err := envelope.Open(ctx, "id-2", sealed)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the value is not base64 encoded", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		dataKey, err := NewKey()
+		assert.NoError(t, err)
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		keyProvider.On("DecryptDataKey", ctx, []byte("wrapped"), 1).
+			Return(dataKey, nil).
+			Once()
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		res, err := envelope.Open(ctx, "id", Sealed{
+			Values:     []string{"John Doe"},
+			DataKey:    base64.StdEncoding.EncodeToString([]byte("wrapped")),
+			KeyVersion: 1,
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the data key is not base64 encoded", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		res, err := envelope.Open(ctx, "id", Sealed{
+			Values:     []string{"value"},
+			DataKey:    "not base64!",
+			KeyVersion: 1,
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		assert.Nil(t, res)
+	})
+
+	t.Run("Should return error if the data key could not be decrypted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		keyProvider := provider.NewMockKeyProvider(t)
+
+		keyProvider.On("DecryptDataKey", ctx, []byte("wrapped"), 1).
+			Return(nil, errors.New("something got wrong")).
+			Once()
+
+		envelope := NewEnvelope(keyProvider)
+
+		// Act
+		res, err := envelope.Open(ctx, "id", Sealed{
+			Values:     []string{"value"},
+			DataKey:    base64.StdEncoding.EncodeToString([]byte("wrapped")),
+			KeyVersion: 1,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,32 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidETag = errors.New("invalid etag")
+
+// Format returns the strong ETag of the given version, as in "3".
+func Format(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// Parse reads the version back from a single strong ETag. Weak ETags,
+// lists and the "*" wildcard are rejected because If-Match must identify
+// exactly the version being replaced.
+func Parse(value string) (int, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) < 3 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version < 1 {
+		return 0, ErrInvalidETag
+	}
+
+	return version, nil
+}
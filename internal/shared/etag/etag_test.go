@@ -0,0 +1,51 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("Should format the version as a strong etag", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Format(3)
+
+		// Assert
+		assert.Equal(t, `"3"`, res)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Should parse a strong etag", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := Parse(` "12" `)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 12, res)
+	})
+
+	t.Run("Should parse a formatted etag", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := Parse(Format(7))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, res)
+	})
+
+	t.Run("Should reject invalid etags", func(t *testing.T) {
+		for _, value := range []string{"", "*", "3", `W/"3"`, `"3", "4"`, `"abc"`, `"0"`, `"-1"`, `""`} {
+			// Arrange
+			// Act
+			_, err := Parse(value)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidETag, value)
+		}
+	})
+}
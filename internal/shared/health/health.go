@@ -1,12 +1,38 @@
 package health
 
+import (
+	"context"
+	"time"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
 type HealthCheck interface {
-	Health() *HealthStatus
+	Health(ctx context.Context) *HealthStatus
 }
 
 type HealthStatus struct {
-	Status string `json:"status"`
-	Err    string `json:"err,omitempty"`
+	Status        string     `json:"status"`
+	Err           string     `json:"err,omitempty"`
+	Latency       string     `json:"latency,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+}
+
+func Healthy() *HealthStatus {
+	return &HealthStatus{
+		Status: StatusHealthy,
+	}
+}
+
+func Unhealthy(err error) *HealthStatus {
+	return &HealthStatus{
+		Status: StatusUnhealthy,
+		Err:    err.Error(),
+	}
 }
 
 func (h *HealthStatus) HasError() bool {
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider"
+)
+
+type Report struct {
+	Status     string                   `json:"status"`
+	Components map[string]*HealthStatus `json:"components"`
+}
+
+// IsReady reports whether the service can take traffic, a degraded service
+// only has optional components failing.
+func (r *Report) IsReady() bool {
+	return r.Status != StatusUnhealthy
+}
+
+// WithoutErrors leaves out the errors of the components, which may tell how
+// the dependencies are reached, and keeps their latency and last success.
+func (r *Report) WithoutErrors() *Report {
+	report := &Report{
+		Status:     r.Status,
+		Components: make(map[string]*HealthStatus, len(r.Components)),
+	}
+
+	for name, status := range r.Components {
+		withoutError := *status
+		withoutError.Err = ""
+
+		report.Components[name] = &withoutError
+	}
+
+	return report
+}
+
+type component struct {
+	name     string
+	check    HealthCheck
+	optional bool
+}
+
+// Registry runs the registered checks in parallel, each one bounded by the
+// timeout, and remembers when each component was last seen healthy.
+type Registry struct {
+	timeout      time.Duration
+	timeProvider provider.TimeProvider
+
+	components []component
+
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
+}
+
+func NewRegistry(timeout time.Duration, timeProvider provider.TimeProvider) *Registry {
+	return &Registry{
+		timeout:      timeout,
+		timeProvider: timeProvider,
+		lastSuccess:  make(map[string]time.Time),
+	}
+}
+
+// Register adds a component the service can't work without.
+func (r *Registry) Register(name string, check HealthCheck) {
+	r.components = append(r.components, component{name: name, check: check})
+}
+
+// RegisterOptional adds a component whose failure degrades the service
+// without making it unready, as the ones only needed on startup.
+func (r *Registry) RegisterOptional(name string, check HealthCheck) {
+	r.components = append(r.components, component{name: name, check: check, optional: true})
+}
+
+func (r *Registry) Check(ctx context.Context) *Report {
+	statuses := make([]*HealthStatus, len(r.components))
+
+	var wg sync.WaitGroup
+
+	for i, component := range r.components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = r.run(ctx, component)
+		}()
+	}
+
+	wg.Wait()
+
+	report := &Report{
+		Status:     StatusHealthy,
+		Components: make(map[string]*HealthStatus, len(r.components)),
+	}
+
+	for i, component := range r.components {
+		status := statuses[i]
+		report.Components[component.name] = status
+
+		if status.Status == StatusHealthy {
+			continue
+		}
+
+		if !component.optional {
+			report.Status = StatusUnhealthy
+		} else if report.Status == StatusHealthy {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, component component) *HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// buffered so a check ignoring the context doesn't leak its goroutine
+	done := make(chan *HealthStatus, 1)
+	go func() {
+		done <- component.check.Health(ctx)
+	}()
+
+	var status HealthStatus
+
+	select {
+	case result := <-done:
+		status = *result
+	case <-ctx.Done():
+		status = *Unhealthy(fmt.Errorf("health check timed out after %s", r.timeout))
+	}
+
+	status.Latency = time.Since(start).String()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if status.Status == StatusHealthy {
+		r.lastSuccess[component.name] = r.timeProvider.GetTime()
+	}
+
+	if lastSuccess, ok := r.lastSuccess[component.name]; ok {
+		status.LastSuccessAt = &lastSuccess
+	}
+
+	return &status
+}
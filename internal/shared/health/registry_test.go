@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/provider/time_provider"
+	"github.com/stretchr/testify/assert"
+)
+
+type checkFunc func(ctx context.Context) *HealthStatus
+
+func (f checkFunc) Health(ctx context.Context) *HealthStatus {
+	return f(ctx)
+}
+
+var healthy = checkFunc(func(ctx context.Context) *HealthStatus {
+	return Healthy()
+})
+
+var unhealthy = checkFunc(func(ctx context.Context) *HealthStatus {
+	return Unhealthy(errors.New("error"))
+})
+
+func newRegistry(timeout time.Duration, now *time.Time) *Registry {
+	return NewRegistry(timeout, time_provider.NewTimeProvider(func() time.Time {
+		return *now
+	}))
+}
+
+func TestRegistry_Check(t *testing.T) {
+	t.Run("Should report healthy when every component is healthy", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		registry := newRegistry(time.Second, &now)
+		registry.Register("database", healthy)
+		registry.RegisterOptional("queue", healthy)
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusHealthy, res.Status)
+		assert.True(t, res.IsReady())
+		assert.Len(t, res.Components, 2)
+		assert.Equal(t, StatusHealthy, res.Components["database"].Status)
+		assert.NotEmpty(t, res.Components["database"].Latency)
+		assert.Equal(t, now, *res.Components["database"].LastSuccessAt)
+	})
+
+	t.Run("Should report unhealthy when a required component is unhealthy", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		registry := newRegistry(time.Second, &now)
+		registry.Register("database", unhealthy)
+		registry.RegisterOptional("queue", healthy)
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusUnhealthy, res.Status)
+		assert.False(t, res.IsReady())
+		assert.Equal(t, "error", res.Components["database"].Err)
+		assert.Nil(t, res.Components["database"].LastSuccessAt)
+	})
+
+	t.Run("Should report degraded when only an optional component is unhealthy", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		registry := newRegistry(time.Second, &now)
+		registry.Register("database", healthy)
+		registry.RegisterOptional("queue", unhealthy)
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusDegraded, res.Status)
+		assert.True(t, res.IsReady())
+	})
+
+	t.Run("Should report unhealthy when a check times out", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		registry := newRegistry(10*time.Millisecond, &now)
+		registry.Register("database", checkFunc(func(ctx context.Context) *HealthStatus {
+			<-release
+			return Healthy()
+		}))
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusUnhealthy, res.Status)
+		assert.Contains(t, res.Components["database"].Err, "timed out")
+	})
+
+	t.Run("Should keep the last success time of a failing component", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+		lastSuccess := now
+
+		failing := false
+
+		registry := newRegistry(time.Second, &now)
+		registry.Register("database", checkFunc(func(ctx context.Context) *HealthStatus {
+			if failing {
+				return Unhealthy(errors.New("error"))
+			}
+			return Healthy()
+		}))
+
+		registry.Check(context.Background())
+
+		now = now.Add(time.Minute)
+		failing = true
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusUnhealthy, res.Status)
+		assert.Equal(t, lastSuccess, *res.Components["database"].LastSuccessAt)
+	})
+
+	t.Run("Should run the checks in parallel", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		slow := checkFunc(func(ctx context.Context) *HealthStatus {
+			time.Sleep(50 * time.Millisecond)
+			return Healthy()
+		})
+
+		registry := newRegistry(time.Second, &now)
+		registry.Register("database", slow)
+		registry.Register("queue", slow)
+		registry.Register("topic", slow)
+
+		start := time.Now()
+
+		// Act
+		res := registry.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusHealthy, res.Status)
+		assert.Less(t, time.Since(start), 140*time.Millisecond)
+	})
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("Should return the logger of the context", func(t *testing.T) {
+		// Arrange
+		logger := slog.Default().With("request_id", "my-request-id")
+
+		ctx := WithLogger(context.Background(), logger)
+
+		// Act
+		res := FromContext(ctx)
+
+		// Assert
+		assert.Same(t, logger, res)
+	})
+
+	t.Run("Should return the default logger when there is none in the context", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		res := FromContext(ctx)
+
+		// Assert
+		assert.Same(t, slog.Default(), res)
+	})
+}
+
+func TestWith(t *testing.T) {
+	t.Run("Should add the fields to the logger of the context", func(t *testing.T) {
+		// Arrange
+		buffer := setupBuffer(t)
+
+		ctx := WithLogger(context.Background(), slog.Default().With("request_id", "my-request-id"))
+
+		// Act
+		ctx = With(ctx, "customer_id", "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		FromContext(ctx).InfoContext(ctx, "message")
+
+		logs := readLogs(t, buffer)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, "my-request-id", logs[0]["request_id"])
+		assert.Equal(t, "733f1ba6-1f62-4495-bf33-6f181fdf1030", logs[0]["customer_id"])
+	})
+}
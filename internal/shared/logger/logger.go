@@ -18,6 +18,10 @@ func SetupLog(config *environment.Config) {
 		logLevel = "debug"
 	}
 
+	if config.ApiConfig.IsLogLevelSet() {
+		logLevel = config.ApiConfig.LogLevel
+	}
+
 	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
 		panic(fmt.Errorf("unable to load log level: %v", err))
 	}
@@ -32,6 +36,6 @@ func SetupLog(config *environment.Config) {
 		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
 
-	log := slog.New(handler)
+	log := slog.New(NewRedactHandler(handler))
 	slog.SetDefault(log)
 }
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"testing"
 
@@ -21,7 +22,7 @@ func TestSetupLog(t *testing.T) {
 		SetupLog(config)
 
 		// Assert
-		assert.IsType(t, &slog.TextHandler{}, slog.Default().Handler())
+		assert.IsType(t, &slog.TextHandler{}, slog.Default().Handler().(*redactHandler).handler)
 	})
 
 	t.Run("Should setup log when is not development", func(t *testing.T) {
@@ -36,6 +37,54 @@ func TestSetupLog(t *testing.T) {
 		SetupLog(config)
 
 		// Assert
-		assert.IsType(t, &slog.JSONHandler{}, slog.Default().Handler())
+		assert.IsType(t, &slog.JSONHandler{}, slog.Default().Handler().(*redactHandler).handler)
+	})
+
+	t.Run("Should setup log with the configured level", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName:  "development",
+				LogLevel: "warn",
+			},
+		}
+
+		// Act
+		SetupLog(config)
+
+		// Assert
+		assert.False(t, slog.Default().Enabled(context.Background(), slog.LevelInfo))
+		assert.True(t, slog.Default().Enabled(context.Background(), slog.LevelWarn))
+	})
+
+	t.Run("Should setup log with the level of the environment when none is configured", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+		}
+
+		// Act
+		SetupLog(config)
+
+		// Assert
+		assert.False(t, slog.Default().Enabled(context.Background(), slog.LevelDebug))
+		assert.True(t, slog.Default().Enabled(context.Background(), slog.LevelInfo))
+	})
+
+	t.Run("Should panic when the level is not valid", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				LogLevel: "verbose",
+			},
+		}
+
+		// Act
+		// Assert
+		assert.Panics(t, func() {
+			SetupLog(config)
+		})
 	})
 }
@@ -1,15 +1,22 @@
 package logger
 
 import (
-	"context"
 	"log/slog"
+	"net/http"
 	"os"
 	"runtime/debug"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const maxRequestIdLength = 128
+
+// Middleware puts a logger with the fields of the request in its context and
+// logs every request once it is handled. The request id is taken from the
+// X-Request-ID header, or generated, and sent back in the response.
 func Middleware() echo.MiddlewareFunc {
 	buildInfo, _ := debug.ReadBuildInfo()
 
@@ -19,24 +26,73 @@ func Middleware() echo.MiddlewareFunc {
 			slog.String("go_version", buildInfo.GoVersion),
 		),
 	)
-	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
-		LogStatus:   true,
-		LogURI:      true,
-		LogError:    true,
-		HandleError: true,
+
+	requestLogger := middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogStatus:        true,
+		LogURI:           true,
+		LogLatency:       true,
+		LogContentLength: true,
+		LogResponseSize:  true,
+		LogError:         true,
+		HandleError:      true,
 		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			ctx := c.Request().Context()
+
+			attrs := []slog.Attr{
+				slog.String("uri", v.URI),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+				slog.String("bytes_in", v.ContentLength),
+				slog.Int64("bytes_out", v.ResponseSize),
+			}
+
 			if v.Error != nil {
-				child.LogAttrs(
-					context.Background(),
-					slog.LevelError,
-					"request error",
-					slog.String("uri", v.URI),
-					slog.Int("status", v.Status),
-					slog.String("err", v.Error.Error()),
-				)
+				attrs = append(attrs, slog.String("err", v.Error.Error()))
 			}
 
+			FromContext(ctx).LogAttrs(ctx, level(v.Status), "request handled", attrs...)
+
 			return nil
 		},
 	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		handler := requestLogger(next)
+
+		return func(c echo.Context) error {
+			requestId := c.Request().Header.Get(echo.HeaderXRequestID)
+			if requestId == "" || len(requestId) > maxRequestIdLength {
+				requestId = uuid.NewString()
+			}
+
+			c.Response().Header().Set(echo.HeaderXRequestID, requestId)
+
+			ctx := c.Request().Context()
+
+			logger := child.With(
+				slog.String("request_id", requestId),
+				slog.String("method", c.Request().Method),
+				slog.String("route", c.Path()),
+			)
+
+			if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+				logger = logger.With(slog.String("trace_id", span.TraceID().String()))
+			}
+
+			c.SetRequest(c.Request().WithContext(WithLogger(ctx, logger)))
+
+			return handler(c)
+		}
+	}
+}
+
+func level(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
 }
@@ -1,15 +1,44 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+func setupBuffer(t *testing.T) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+	})
+
+	return buffer
+}
+
+func readLogs(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	logs := make([]map[string]any, 0)
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		log := map[string]any{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &log))
+		logs = append(logs, log)
+	}
+
+	return logs
+}
+
 func TestMiddleware(t *testing.T) {
 	t.Run("Should return middleware", func(t *testing.T) {
 		// Arrange
@@ -21,26 +50,119 @@ func TestMiddleware(t *testing.T) {
 		assert.NotNil(t, middleware)
 	})
 
-	t.Run("Should return middleware with request logger", func(t *testing.T) {
+	t.Run("Should log the handled request with the fields of the request", func(t *testing.T) {
 		// Arrange
-		middleware := Middleware()
+		buffer := setupBuffer(t)
 
-		trigger := func(c echo.Context) error {
-			return echo.NewHTTPError(http.StatusBadRequest, errors.New("this is a test"))
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/customers/:id", func(c echo.Context) error {
+			ctx := With(c.Request().Context(), "customer_id", "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			FromContext(ctx).InfoContext(ctx, "customer found")
+
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/123", nil)
+		req.Header.Set(echo.HeaderXRequestID, "my-request-id")
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "my-request-id", resp.Header().Get(echo.HeaderXRequestID))
+
+		logs := readLogs(t, buffer)
+		assert.Len(t, logs, 2)
+
+		for _, log := range logs {
+			assert.Equal(t, "my-request-id", log["request_id"])
+			assert.Equal(t, "/customers/:id", log["route"])
+			assert.Equal(t, "733f1ba6-1f62-4495-bf33-6f181fdf1030", log["customer_id"])
 		}
 
-		req := httptest.NewRequest(echo.GET, "/", nil)
+		assert.Equal(t, "customer found", logs[0]["msg"])
+		assert.Equal(t, "request handled", logs[1]["msg"])
+		assert.Equal(t, "INFO", logs[1]["level"])
+		assert.Equal(t, float64(http.StatusOK), logs[1]["status"])
+		assert.Equal(t, float64(2), logs[1]["bytes_out"])
+		assert.Contains(t, logs[1], "latency")
+	})
+
+	t.Run("Should generate the request id when there is none", func(t *testing.T) {
+		// Arrange
+		buffer := setupBuffer(t)
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		resp := httptest.NewRecorder()
 
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		requestId := resp.Header().Get(echo.HeaderXRequestID)
+		assert.Len(t, requestId, 36)
+
+		logs := readLogs(t, buffer)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, requestId, logs[0]["request_id"])
+	})
+
+	t.Run("Should replace a request id that is too long", func(t *testing.T) {
+		// Arrange
+		setupBuffer(t)
+
 		e := echo.New()
-		e.Use(middleware)
+		e.Use(Middleware())
+		e.GET("/", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, strings.Repeat("a", 129))
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Len(t, resp.Header().Get(echo.HeaderXRequestID), 36)
+	})
+
+	t.Run("Should log the request error", func(t *testing.T) {
+		// Arrange
+		buffer := setupBuffer(t)
 
-		ctx := e.NewContext(req, resp)
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/bad-request", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusBadRequest, errors.New("this is a test"))
+		})
+		e.GET("/internal-error", func(c echo.Context) error {
+			return errors.New("this is a test")
+		})
 
 		// Act
-		err := trigger(ctx)
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bad-request", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/internal-error", nil))
 
 		// Assert
-		assert.Error(t, err)
+		logs := readLogs(t, buffer)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, "WARN", logs[0]["level"])
+		assert.Equal(t, float64(http.StatusBadRequest), logs[0]["status"])
+		assert.Contains(t, logs[0]["err"], "this is a test")
+		assert.Equal(t, "ERROR", logs[1]["level"])
+		assert.Equal(t, float64(http.StatusInternalServerError), logs[1]["status"])
 	})
 }
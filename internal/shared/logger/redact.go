@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/mask"
+)
+
+// sensitiveKeys are masked whatever the logger, or the group, they are
+// logged with, so a field logged by mistake never reaches the logs as is.
+var sensitiveKeys = map[string]func(string) string{
+	"document":         mask.Document,
+	"document_id":      mask.Document,
+	"name":             mask.Name,
+	"address":          mask.Address,
+	"phone":            mask.Phone,
+	"password":         redact,
+	"current_password": redact,
+	"secret":           redact,
+	"token":            redact,
+	"access_token":     redact,
+	"refresh_token":    redact,
+	"authorization":    redact,
+}
+
+type redactHandler struct {
+	handler slog.Handler
+}
+
+func NewRedactHandler(handler slog.Handler) slog.Handler {
+	return &redactHandler{
+		handler: handler,
+	}
+}
+
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		redacted = append(redacted, redactAttr(attr))
+	}
+
+	return &redactHandler{
+		handler: h.handler.WithAttrs(redacted),
+	}
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{
+		handler: h.handler.WithGroup(name),
+	}
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	// the types logging themselves, as the entities, already chose what to
+	// show, masking their fields again would only garble them
+	if attr.Value.Kind() == slog.KindLogValuer {
+		return slog.Attr{Key: attr.Key, Value: attr.Value.Resolve()}
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+
+		redacted := make([]slog.Attr, 0, len(group))
+		for _, attr := range group {
+			redacted = append(redacted, redactAttr(attr))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	}
+
+	if maskValue, ok := sensitiveKeys[strings.ToLower(attr.Key)]; ok {
+		return slog.String(attr.Key, maskValue(attr.Value.String()))
+	}
+
+	return attr
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return mask.Redacted
+}
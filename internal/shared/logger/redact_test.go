@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/entity"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRedactedLogger() (*slog.Logger, *bytes.Buffer) {
+	buffer := &bytes.Buffer{}
+
+	return slog.New(NewRedactHandler(slog.NewJSONHandler(buffer, nil))), buffer
+}
+
+func decode(t *testing.T, buffer *bytes.Buffer) map[string]any {
+	log := map[string]any{}
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &log))
+
+	return log
+}
+
+func TestRedactHandler(t *testing.T) {
+	t.Run("Should mask the sensitive fields", func(t *testing.T) {
+		// Arrange
+		logger, buffer := newRedactedLogger()
+
+		// Act
+		logger.InfoContext(context.Background(), "message",
+			"document_id", "12345678901",
+			"name", "John Doe",
+			"address", "Av. Brasil, 1000",
+			"phone", "1122334455",
+			"password", "my-password",
+			"customer_id", "733f1ba6-1f62-4495-bf33-6f181fdf1030")
+
+		// Assert
+		log := decode(t, buffer)
+		assert.Equal(t, "***.456.789-**", log["document_id"])
+		assert.Equal(t, "J*** D**", log["name"])
+		assert.Equal(t, "[REDACTED]", log["address"])
+		assert.Equal(t, "********55", log["phone"])
+		assert.Equal(t, "[REDACTED]", log["password"])
+		assert.Equal(t, "733f1ba6-1f62-4495-bf33-6f181fdf1030", log["customer_id"])
+	})
+
+	t.Run("Should mask the sensitive fields of the logger and of the groups", func(t *testing.T) {
+		// Arrange
+		logger, buffer := newRedactedLogger()
+
+		// Act
+		logger.With("phone", "1122334455").
+			WithGroup("request").
+			InfoContext(context.Background(), "message", slog.Group("customer", slog.String("Password", "my-password")))
+
+		// Assert
+		log := decode(t, buffer)
+		assert.Equal(t, "********55", log["phone"])
+		assert.Equal(t, map[string]any{
+			"customer": map[string]any{
+				"Password": "[REDACTED]",
+			},
+		}, log["request"])
+	})
+
+	t.Run("Should log the customer without its document and password", func(t *testing.T) {
+		// Arrange
+		logger, buffer := newRedactedLogger()
+
+		customer := entity.NewCustomer("12345678901", document.TypeCPF, "my-password", time.Now())
+
+		// Act
+		logger.InfoContext(context.Background(), "message", "customer", customer)
+
+		// Assert
+		assert.NotContains(t, buffer.String(), "12345678901")
+		assert.NotContains(t, buffer.String(), "my-password")
+
+		log := decode(t, buffer)
+		assert.Equal(t, "***.456.789-**", log["customer"].(map[string]any)["document_id"])
+	})
+
+	t.Run("Should log the deletion request without the contact data", func(t *testing.T) {
+		// Arrange
+		logger, buffer := newRedactedLogger()
+
+		request := entity.NewDeleteRequest("733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			"John Doe",
+			"Av. Brasil, 1000",
+			"1122334455",
+			time.Now(),
+			time.Hour)
+
+		// Act
+		logger.InfoContext(context.Background(), "message", "request", request)
+
+		// Assert
+		assert.NotContains(t, buffer.String(), "John Doe")
+		assert.NotContains(t, buffer.String(), "Av. Brasil")
+		assert.NotContains(t, buffer.String(), "1122334455")
+
+		log := decode(t, buffer)
+		assert.Equal(t, map[string]any{
+			"id":            request.Id,
+			"customer_id":   "733f1ba6-1f62-4495-bf33-6f181fdf1030",
+			"name":          "J*** D**",
+			"address":       "[REDACTED]",
+			"phone":         "********55",
+			"status":        "pending",
+			"attempts":      float64(0),
+			"scheduled_for": request.ScheduledFor.Format(time.RFC3339Nano),
+		}, log["request"])
+	})
+}
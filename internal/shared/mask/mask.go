@@ -0,0 +1,67 @@
+package mask
+
+import (
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+)
+
+const (
+	maskChar = "*"
+
+	// Redacted replaces the values that must not be seen at all
+	Redacted = "[REDACTED]"
+)
+
+// Document masks a CPF keeping only its middle digits visible, as in
+// ***.456.789-**. Any other value has everything but the last two
+// characters masked.
+func Document(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	digits := document.Normalize(value)
+
+	if len(digits) == 11 {
+		return "***." + digits[3:6] + "." + digits[6:9] + "-**"
+	}
+
+	return lastCharacters(value, 2)
+}
+
+// Name keeps only the initial of each name, as in J*** D**.
+func Name(value string) string {
+	names := strings.Fields(value)
+
+	for i, name := range names {
+		runes := []rune(name)
+		names[i] = string(runes[0]) + strings.Repeat(maskChar, len(runes)-1)
+	}
+
+	return strings.Join(names, " ")
+}
+
+// Phone keeps only the last two digits visible.
+func Phone(value string) string {
+	return lastCharacters(value, 2)
+}
+
+// Address has nothing worth keeping visible, it is fully redacted.
+func Address(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return Redacted
+}
+
+func lastCharacters(value string, visible int) string {
+	runes := []rune(value)
+
+	if len(runes) <= visible {
+		return strings.Repeat(maskChar, len(runes))
+	}
+
+	return strings.Repeat(maskChar, len(runes)-visible) + string(runes[len(runes)-visible:])
+}
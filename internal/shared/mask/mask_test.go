@@ -0,0 +1,114 @@
+package mask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	t.Run("Should mask a cpf", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Document("12345678901")
+
+		// Assert
+		assert.Equal(t, "***.456.789-**", res)
+	})
+
+	t.Run("Should mask a formatted cpf", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Document("123.456.789-01")
+
+		// Assert
+		assert.Equal(t, "***.456.789-**", res)
+	})
+
+	t.Run("Should keep only the last characters of other documents", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Document("ABC1234")
+
+		// Assert
+		assert.Equal(t, "*****34", res)
+	})
+
+	t.Run("Should mask short documents entirely", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Document("12")
+
+		// Assert
+		assert.Equal(t, "**", res)
+	})
+
+	t.Run("Should return empty when there is no document", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Document("")
+
+		// Assert
+		assert.Empty(t, res)
+	})
+}
+
+func TestName(t *testing.T) {
+	t.Run("Should keep only the initials", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Name("John  Doe")
+
+		// Assert
+		assert.Equal(t, "J*** D**", res)
+	})
+
+	t.Run("Should mask names with accents", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Name("João")
+
+		// Assert
+		assert.Equal(t, "J***", res)
+	})
+
+	t.Run("Should return empty when there is no name", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Name("")
+
+		// Assert
+		assert.Empty(t, res)
+	})
+}
+
+func TestPhone(t *testing.T) {
+	t.Run("Should keep only the last digits", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Phone("1122334455")
+
+		// Assert
+		assert.Equal(t, "********55", res)
+	})
+}
+
+func TestAddress(t *testing.T) {
+	t.Run("Should redact the address", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Address("Av. Brasil, 1000")
+
+		// Assert
+		assert.Equal(t, Redacted, res)
+	})
+
+	t.Run("Should return empty when there is no address", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := Address("")
+
+		// Assert
+		assert.Empty(t, res)
+	})
+}
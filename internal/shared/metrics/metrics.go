@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "customer_management"
+
+// Registry holds the metrics exposed on /metrics, kept apart from the default
+// one so only the collectors of this service are scraped.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HttpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of the HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DeletionRequestsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletion_requests_created_total",
+		Help:      "Deletion requests created.",
+	})
+
+	DeletionRequestsDuplicated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletion_requests_duplicated_total",
+		Help:      "Deletion requests rejected because the customer already has an active one.",
+	})
+
+	DeletionRequestsExecuted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletion_requests_executed_total",
+		Help:      "Deletion requests executed.",
+	})
+
+	DeletionRequestAttemptsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletion_request_attempts_failed_total",
+		Help:      "Deletion request executions that failed, including the ones retried later.",
+	})
+
+	DeletionRequestsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletion_requests_failed_total",
+		Help:      "Deletion requests that failed all their attempts and are no longer retried.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HttpRequestDuration,
+		DeletionRequestsCreated,
+		DeletionRequestsDuplicated,
+		DeletionRequestsExecuted,
+		DeletionRequestAttemptsFailed,
+		DeletionRequestsFailed,
+	)
+}
+
+// RegisterDatabase exposes the pool stats of the connection, replacing the
+// ones of a connection registered before under the same name.
+func RegisterDatabase(name string, db *sql.DB) error {
+	collector := collectors.NewDBStatsCollector(db, name)
+
+	err := Registry.Register(collector)
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		Registry.Unregister(alreadyRegistered.ExistingCollector)
+		return Registry.Register(collector)
+	}
+
+	return err
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterDatabase(t *testing.T) {
+	t.Run("Should expose the pool stats of the database", func(t *testing.T) {
+		// Arrange
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// Act
+		err = RegisterDatabase("postgres", db)
+
+		// Assert
+		assert.NoError(t, err)
+
+		count, err := testutil.GatherAndCount(Registry, "go_sql_max_open_connections")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Should replace the database registered with the same name", func(t *testing.T) {
+		// Arrange
+		first, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer first.Close()
+
+		second, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer second.Close()
+
+		err = RegisterDatabase("postgres", first)
+		assert.NoError(t, err)
+
+		// Act
+		err = RegisterDatabase("postgres", second)
+
+		// Assert
+		assert.NoError(t, err)
+
+		count, err := testutil.GatherAndCount(Registry, "go_sql_max_open_connections")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleCount(t *testing.T, labels ...string) uint64 {
+	metric := &dto.Metric{}
+
+	err := HttpRequestDuration.WithLabelValues(labels...).(prometheus.Metric).Write(metric)
+	assert.NoError(t, err)
+
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should record the request duration by route and status", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/customers/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, uint64(1), sampleCount(t, http.MethodGet, "/customers/:id", "204"))
+	})
+
+	t.Run("Should record the status of the returned error", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/bad-request", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+		})
+		e.GET("/internal-error", func(c echo.Context) error {
+			return errors.New("error")
+		})
+
+		// Act
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bad-request", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/internal-error", nil))
+
+		// Assert
+		assert.Equal(t, uint64(1), sampleCount(t, http.MethodGet, "/bad-request", "400"))
+		assert.Equal(t, uint64(1), sampleCount(t, http.MethodGet, "/internal-error", "500"))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Should expose the registered metrics", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.GET("/metrics", Handler())
+
+		DeletionRequestsCreated.Inc()
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, strings.Contains(resp.Body.String(), "customer_management_deletion_requests_created_total"))
+	})
+}
@@ -0,0 +1,18 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+// Middleware starts a span for each request, continuing the trace of the
+// traceparent header when there is one. The probes and the scrapes are not
+// traced, they would only add noise.
+func Middleware(serviceName string) echo.MiddlewareFunc {
+	return otelecho.Middleware(serviceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		path := c.Request().URL.Path
+		return strings.HasPrefix(path, "/health") || path == "/metrics"
+	}))
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/jfelipearaujo-org/ms-customer-management"
+
+type Shutdown func(ctx context.Context) error
+
+// Setup registers the global tracer provider and the W3C trace context
+// propagator. The spans are only exported when the OTLP exporter is enabled,
+// otherwise they are dropped once ended.
+func Setup(ctx context.Context, config *environment.Config) (Shutdown, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(config.TracingConfig.ServiceName),
+			semconv.DeploymentEnvironment(config.ApiConfig.EnvName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracingConfig.SampleRatio))),
+	}
+
+	if config.TracingConfig.IsOtlpEnabled() {
+		exporterOptions := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.TracingConfig.Endpoint),
+		}
+
+		if config.TracingConfig.Insecure {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End flags the span as failed when there is an error before ending it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
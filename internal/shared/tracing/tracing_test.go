@@ -0,0 +1,175 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/environment"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("Should setup the tracing without exporting the spans", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "development",
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "none",
+				ServiceName: "ms-customer-management",
+				SampleRatio: 1,
+			},
+		}
+
+		// Act
+		shutdown, err := Setup(ctx, config)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, shutdown)
+
+		_, span := Start(ctx, "span")
+		assert.True(t, span.SpanContext().IsValid())
+		span.End()
+
+		assert.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("Should setup the tracing with the otlp exporter", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "otlp",
+				Endpoint:    "localhost:4318",
+				Insecure:    true,
+				ServiceName: "ms-customer-management",
+				SampleRatio: 1,
+			},
+		}
+
+		// Act
+		shutdown, err := Setup(ctx, config)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, shutdown)
+	})
+}
+
+func TestEnd(t *testing.T) {
+	t.Run("Should end the span", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		_, span := Start(context.Background(), "span")
+
+		// Act
+		End(span, nil)
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("Should flag the span as failed when there is an error", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		_, span := Start(context.Background(), "span")
+
+		// Act
+		End(span, errors.New("error"))
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "error", spans[0].Status().Description)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should continue the trace of the traceparent header", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		var handlerSpan trace.SpanContext
+
+		e := echo.New()
+		e.Use(Middleware("ms-customer-management"))
+		e.GET("/customers/me", func(c echo.Context) error {
+			handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/me", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerSpan.TraceID().String())
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	})
+
+	t.Run("Should not trace the health checks", func(t *testing.T) {
+		// Arrange
+		recorder := setupRecorder(t)
+
+		e := echo.New()
+		e.Use(Middleware("ms-customer-management"))
+		e.GET("/health/live", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, recorder.Ended())
+	})
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jfelipearaujo-org/ms-customer-management/internal/shared/document"
+)
+
+var (
+	instance *validator.Validate
+	once     sync.Once
+)
+
+// Default returns the validator shared by the request structs, with the
+// custom tags of the service registered. The validator caches the structs
+// it has seen, so it is created only once.
+func Default() *validator.Validate {
+	once.Do(func() {
+		instance = validator.New()
+
+		if err := document.RegisterValidations(instance); err != nil {
+			panic(err)
+		}
+
+		if err := instance.RegisterValidation("maxbytes", maxBytes); err != nil {
+			panic(err)
+		}
+	})
+
+	return instance
+}
+
+// maxBytes limits the length of a string in bytes, the max tag counts runes
+// but bcrypt refuses passwords longer than 72 bytes.
+func maxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(err)
+	}
+
+	return len(fl.Field().String()) <= limit
+}
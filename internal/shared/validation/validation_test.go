@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type request struct {
+	Document string `validate:"omitempty,document"`
+	Cpf      string `validate:"omitempty,cpf"`
+	Cnpj     string `validate:"omitempty,cnpj"`
+	Password string `validate:"omitempty,maxbytes=72"`
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("Should return the same validator", func(t *testing.T) {
+		// Arrange
+		// Act
+		first := Default()
+		second := Default()
+
+		// Assert
+		assert.Same(t, first, second)
+	})
+
+	t.Run("Should validate the document tags", func(t *testing.T) {
+		// Arrange
+		validate := Default()
+
+		// Act
+		err := validate.Struct(request{
+			Document: "11.222.333/0001-81",
+			Cpf:      "529.982.247-25",
+			Cnpj:     "11222333000181",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when the documents are not valid", func(t *testing.T) {
+		// Arrange
+		validate := Default()
+
+		// Act
+		documentErr := validate.Struct(request{Document: "12345678901"})
+		cpfErr := validate.Struct(request{Cpf: "11222333000181"})
+		cnpjErr := validate.Struct(request{Cnpj: "52998224725"})
+
+		// Assert
+		assert.Error(t, documentErr)
+		assert.Error(t, cpfErr)
+		assert.Error(t, cnpjErr)
+	})
+
+	t.Run("Should limit the length in bytes", func(t *testing.T) {
+		// Arrange
+		validate := Default()
+
+		// Act
+		asciiErr := validate.Struct(request{Password: strings.Repeat("a", 72)})
+		multibyteErr := validate.Struct(request{Password: strings.Repeat("ç", 40)})
+
+		// Assert
+		assert.NoError(t, asciiErr)
+		assert.Error(t, multibyteErr)
+	})
+}
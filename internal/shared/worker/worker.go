@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+type stopSignalKey struct{}
+
+// Stopping reports whether the worker running the job was asked to stop, so
+// long jobs can leave between batches. It is always false outside a worker.
+func Stopping(ctx context.Context) bool {
+	stop, ok := ctx.Value(stopSignalKey{}).(<-chan struct{})
+	if !ok {
+		return false
+	}
+
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      Job
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewWorker(name string, interval time.Duration, job Job) *Worker {
+	return &Worker{
+		name:     name,
+		interval: interval,
+		job:      job,
+	}
+}
+
+// Start runs the job right away and then once every interval until Stop is
+// called or the given context is cancelled. It does not block.
+func (w *Worker) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go w.run(ctx, w.done)
+}
+
+// Stop signals the worker to stop and waits for the running job to finish
+// or for the given context to expire, whichever comes first.
+func (w *Worker) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	slog.InfoContext(ctx, "worker started", "worker", w.name, "interval", w.interval)
+
+	for {
+		// the job is not interrupted by a stop request, it is allowed to
+		// finish the current batch before the worker exits
+		jobCtx := context.WithValue(context.WithoutCancel(ctx), stopSignalKey{}, ctx.Done())
+
+		if err := w.job(jobCtx); err != nil {
+			slog.ErrorContext(ctx, "worker job failed", "worker", w.name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "worker stopped", "worker", w.name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker(t *testing.T) {
+	t.Run("Should run the job until it is stopped", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+
+		worker := NewWorker("test", 10*time.Millisecond, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+		// Act
+		worker.Start(context.Background())
+
+		assert.Eventually(t, func() bool {
+			return calls.Load() >= 3
+		}, time.Second, 5*time.Millisecond)
+
+		err := worker.Stop(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+
+		stoppedAt := calls.Load()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, stoppedAt, calls.Load())
+	})
+
+	t.Run("Should keep running when the job fails", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+
+		worker := NewWorker("test", 10*time.Millisecond, func(ctx context.Context) error {
+			calls.Add(1)
+			return errors.New("error")
+		})
+
+		// Act
+		worker.Start(context.Background())
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			return calls.Load() >= 2
+		}, time.Second, 5*time.Millisecond)
+		assert.NoError(t, worker.Stop(context.Background()))
+	})
+
+	t.Run("Should wait for the running job to finish", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		var finished atomic.Bool
+
+		worker := NewWorker("test", time.Hour, func(ctx context.Context) error {
+			close(started)
+			time.Sleep(20 * time.Millisecond)
+			finished.Store(ctx.Err() == nil)
+			return nil
+		})
+
+		worker.Start(context.Background())
+		<-started
+
+		// Act
+		err := worker.Stop(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, finished.Load())
+	})
+
+	t.Run("Should signal the running job to stop", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		var stopping atomic.Bool
+
+		worker := NewWorker("test", time.Hour, func(ctx context.Context) error {
+			close(started)
+			assert.Eventually(t, func() bool {
+				return Stopping(ctx)
+			}, time.Second, 5*time.Millisecond)
+			stopping.Store(Stopping(ctx) && ctx.Err() == nil)
+			return nil
+		})
+
+		worker.Start(context.Background())
+		<-started
+
+		// Act
+		err := worker.Stop(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, stopping.Load())
+	})
+
+	t.Run("Should return an error when the job does not finish in time", func(t *testing.T) {
+		// Arrange
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		worker := NewWorker("test", time.Hour, func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+
+		worker.Start(context.Background())
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := worker.Stop(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Should do nothing when stopping a worker that was not started", func(t *testing.T) {
+		// Arrange
+		worker := NewWorker("test", time.Hour, func(ctx context.Context) error {
+			return nil
+		})
+
+		// Act
+		err := worker.Stop(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestStopping(t *testing.T) {
+	t.Run("Should return false outside a worker", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		stopping := Stopping(ctx)
+
+		// Assert
+		assert.False(t, stopping)
+	})
+}
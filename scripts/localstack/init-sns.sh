@@ -0,0 +1,6 @@
+#!/bin/sh
+
+echo "Initializing SNS..."
+
+awslocal sns create-topic \
+    --name customer-deletion-topic
\ No newline at end of file
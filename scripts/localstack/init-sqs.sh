@@ -0,0 +1,10 @@
+#!/bin/sh
+
+echo "Initializing SQS..."
+
+awslocal sqs create-queue \
+    --queue-name customer-deletion-queue-dlq
+
+awslocal sqs create-queue \
+    --queue-name customer-deletion-queue \
+    --attributes '{"RedrivePolicy":"{\"deadLetterTargetArn\":\"arn:aws:sqs:us-east-1:000000000000:customer-deletion-queue-dlq\",\"maxReceiveCount\":\"5\"}"}'
\ No newline at end of file
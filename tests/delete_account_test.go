@@ -3,9 +3,11 @@ package tests
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"testing"
 	"time"
 
@@ -21,6 +23,8 @@ import (
 	"github.com/jfelipearaujo/testcontainers/pkg/testsuite"
 	"github.com/labstack/echo/v4"
 	"github.com/testcontainers/testcontainers-go"
+
+	_ "github.com/lib/pq"
 )
 
 type feature struct {
@@ -60,7 +64,6 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 				"POSTGRES_USER":     "customer",
 				"POSTGRES_PASSWORD": "customer",
 			}),
-			container.WithFiles(postgres.BasePath, "./testdata/init-db.sql"),
 		)
 
 		pgContainer, err := pgDefinition.BuildContainer(ctx)
@@ -87,15 +90,17 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 				KeepImage:  false,
 			}),
 			container.WithEnvVars(map[string]string{
-				"API_PORT":              "5000",
-				"API_ENV_NAME":          "development",
-				"API_VERSION":           "v1",
-				"DB_URL":                "todo",
-				"DB_URL_SECRET_NAME":    "db-secret-url",
-				"AWS_ACCESS_KEY_ID":     "test",
-				"AWS_SECRET_ACCESS_KEY": "test",
-				"AWS_REGION":            "us-east-1",
-				"AWS_BASE_ENDPOINT":     fmt.Sprintf("http://%s:4566", ntwrkDefinition.Alias),
+				"API_PORT":                    "5000",
+				"API_ENV_NAME":                "development",
+				"API_VERSION":                 "v1",
+				"DB_URL":                      "todo",
+				"DB_URL_SECRET_NAME":          "db-secret-url",
+				"AUTH_SECRET_NAME":            "jwt-secret",
+				"ENCRYPTION_KEYS_SECRET_NAME": "encryption-keys",
+				"AWS_ACCESS_KEY_ID":           "test",
+				"AWS_SECRET_ACCESS_KEY":       "test",
+				"AWS_REGION":                  "us-east-1",
+				"AWS_BASE_ENDPOINT":           fmt.Sprintf("http://%s:4566", ntwrkDefinition.Alias),
 			}),
 			container.WithExposedPorts("5000"),
 			container.WithWaitingForLog("Server started", 10*time.Second),
@@ -106,6 +111,12 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 			return ctx, err
 		}
 
+		// the api migrates the database on startup, so it can only be seeded
+		// once the api is up
+		if err := seedDatabase(ctx, pgContainer); err != nil {
+			return ctx, err
+		}
+
 		host, err := apiContainer.Host(ctx)
 		if err != nil {
 			return ctx, fmt.Errorf("failed to get the host: %w", err)
@@ -139,6 +150,35 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 	})
 }
 
+func seedDatabase(ctx context.Context, pgContainer testcontainers.Container) error {
+	host, err := pgContainer.Host(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the database host: %w", err)
+	}
+
+	port, err := container.GetMappedPort(ctx, pgContainer, nat.Port("5432/tcp"))
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", fmt.Sprintf("postgres://customer:customer@%s:%s/customer_db?sslmode=disable", host, port.Port()))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	seed, err := os.ReadFile("./testdata/seed.sql")
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, string(seed)); err != nil {
+		return fmt.Errorf("failed to seed the database: %w", err)
+	}
+
+	return nil
+}
+
 func generateToken(userId string, expire time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"sub": userId,
@@ -0,0 +1,3 @@
+INSERT INTO customers (id, document_id, document_type, is_anonymous, password, created_at, updated_at)
+VALUES ('19b5408e-8ee2-47d4-953b-196d41f1e367', '52998224725', 1, false, '12345678', NOW(), NOW())
+ON CONFLICT (id) DO NOTHING;